@@ -2,6 +2,7 @@ package astutil
 
 import (
 	"slices"
+	"sort"
 	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
@@ -71,3 +72,41 @@ func filterBuiltinDirectives(dirs map[string]*ast.DirectiveDefinition) map[strin
 	}
 	return result
 }
+
+// ArgumentCoordinate returns the schema coordinate of the named argument of the field or directive with the given
+// coordinate, like 'Query.user(id:)' for a field argument, or '@auth(role:)' for a directive argument.
+func ArgumentCoordinate(parent, argName string) string {
+	return parent + "(" + argName + ":)"
+}
+
+// LinkPossibleTypes rebuilds the PossibleTypes and Implements maps of the given schema from the interfaces and
+// union members declared by the types in s.Types. References to types which are not in s.Types are skipped.
+func LinkPossibleTypes(s *ast.Schema) {
+	s.PossibleTypes = map[string][]*ast.Definition{}
+	s.Implements = map[string][]*ast.Definition{}
+
+	for _, name := range SortedKeys(s.Types) {
+		def := s.Types[name]
+		for _, iface := range def.Interfaces {
+			if ifaceDef := s.Types[iface]; ifaceDef != nil {
+				s.PossibleTypes[iface] = append(s.PossibleTypes[iface], def)
+				s.Implements[name] = append(s.Implements[name], ifaceDef)
+			}
+		}
+		for _, member := range def.Types {
+			if memberDef := s.Types[member]; memberDef != nil {
+				s.PossibleTypes[name] = append(s.PossibleTypes[name], memberDef)
+			}
+		}
+	}
+}
+
+// SortedKeys returns the keys of the given map in lexical order.
+func SortedKeys[T any](m map[string]T) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
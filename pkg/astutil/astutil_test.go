@@ -0,0 +1,34 @@
+package astutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestLinkPossibleTypes(t *testing.T) {
+	node := &ast.Definition{Kind: ast.Interface, Name: "Node"}
+	user := &ast.Definition{Kind: ast.Object, Name: "User", Interfaces: []string{"Node", "Missing"}}
+	team := &ast.Definition{Kind: ast.Object, Name: "Team", Interfaces: []string{"Node"}}
+	actor := &ast.Definition{Kind: ast.Union, Name: "Actor", Types: []string{"User", "Team", "Missing"}}
+
+	s := &ast.Schema{
+		Types: map[string]*ast.Definition{
+			"Node":  node,
+			"User":  user,
+			"Team":  team,
+			"Actor": actor,
+		},
+	}
+	LinkPossibleTypes(s)
+
+	assert.Equal(t, map[string][]*ast.Definition{
+		"Node":  {team, user},
+		"Actor": {user, team},
+	}, s.PossibleTypes)
+	assert.Equal(t, map[string][]*ast.Definition{
+		"User": {node},
+		"Team": {node},
+	}, s.Implements)
+}
@@ -12,6 +12,18 @@ type CLI struct {
 	Introspection IntrospectionCmd `cmd:"" help:"Interact with a GraphQL introspection endpoint over HTTP."`
 	Viz           VizCmd           `cmd:"" help:"Visualize a GraphQL schema using GraphViz."`
 	Merge         MergeCmd         `cmd:"" help:"Merge multiple GraphQL SDL documents into a single one."`
+	Extract       ExtractCmd       `cmd:"" aliases:"prune" help:"Extract a subset of a GraphQL schema as valid GraphQL SDL."`
+	Contract      ContractCmd      `cmd:"" help:"Produce a variant of a GraphQL schema with elements filtered by the directives applied to them."`
+	Diff          DiffCmd          `cmd:"" help:"Compare two versions of a GraphQL schema and classify the changes between them."`
+	Docs          DocsCmd          `cmd:"" help:"Generate Markdown documentation for a GraphQL SDL document."`
+	Lint          LintCmd          `cmd:"" help:"Check a GraphQL schema against a set of configurable lint rules."`
+	Validate      ValidateCmd      `cmd:"" help:"Validate GraphQL operation documents against a schema."`
+	Coverage      CoverageCmd      `cmd:"" help:"Report which parts of a schema are used by a set of GraphQL operation documents."`
+	Deprecations  DeprecationsCmd  `cmd:"" help:"List deprecated elements of a GraphQL schema, and the operations which still use them."`
+	Paths         PathsCmd         `cmd:"" help:"List paths through a GraphQL schema from one type or field to another."`
+	Stats         StatsCmd         `cmd:"" help:"Report statistics about the size and shape of a GraphQL schema."`
+	Query         QueryCmd         `cmd:"" help:"Execute an introspection query against a GraphQL SDL document."`
+	Serve         ServeCmd         `cmd:"" help:"Serve a mock GraphQL API for a GraphQL SDL document over HTTP."`
 	VersionFlag   versionFlag      `hidden:"" help:"Print version and exit."`
 	Version       VersionCmd       `cmd:"" help:"Print the version of gquil and exit."`
 }
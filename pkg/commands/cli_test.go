@@ -14,11 +14,51 @@ type TestCaseParams struct {
 	Dir                string
 	Args               []string `yaml:"args"`
 	ExpectJson         bool     `yaml:"expectJson"`
+	ExpectError        bool     `yaml:"expectError"`
 	ExpectedOutput     string
 	expectedOutputPath string
 }
 
 func TestCli(t *testing.T) {
+	cases := loadTestCases(t)
+
+	for _, tc := range cases {
+		t.Run(tc.Dir, func(t *testing.T) {
+			parser, err := MakeParser()
+			assert.NoError(t, err)
+
+			ctx, err := parser.Parse(tc.Args)
+			assert.NoError(t, err)
+
+			var stdoutBuf, stderrBuf, stdinBuf bytes.Buffer
+
+			err = ctx.Run(Context{
+				Stdout: &stdoutBuf,
+				Stderr: &stderrBuf,
+				Stdin:  &stdinBuf,
+			})
+			if tc.ExpectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			updateExpected := os.Getenv("TEST_UPDATE_EXPECTED")
+			if updateExpected != "" {
+				err = os.WriteFile(tc.expectedOutputPath, stdoutBuf.Bytes(), 0655)
+				assert.NoError(t, err)
+			}
+
+			if tc.ExpectJson {
+				assert.JSONEq(t, tc.ExpectedOutput, stdoutBuf.String())
+			} else {
+				assert.Equal(t, tc.ExpectedOutput, stdoutBuf.String())
+			}
+		})
+	}
+}
+
+func loadTestCases(t *testing.T) []TestCaseParams {
 	var cases []TestCaseParams
 
 	baseDir := "testdata/cases"
@@ -55,34 +95,5 @@ func TestCli(t *testing.T) {
 		cases = append(cases, params)
 	}
 
-	for _, tc := range cases {
-		t.Run(tc.Dir, func(t *testing.T) {
-			parser, err := MakeParser()
-			assert.NoError(t, err)
-
-			ctx, err := parser.Parse(tc.Args)
-			assert.NoError(t, err)
-
-			var stdoutBuf, stderrBuf, stdinBuf bytes.Buffer
-
-			err = ctx.Run(Context{
-				Stdout: &stdoutBuf,
-				Stderr: &stderrBuf,
-				Stdin:  &stdinBuf,
-			})
-			assert.NoError(t, err)
-
-			updateExpected := os.Getenv("TEST_UPDATE_EXPECTED")
-			if updateExpected != "" {
-				err = os.WriteFile(tc.expectedOutputPath, stdoutBuf.Bytes(), 0655)
-				assert.NoError(t, err)
-			}
-
-			if tc.ExpectJson {
-				assert.JSONEq(t, tc.ExpectedOutput, stdoutBuf.String())
-			} else {
-				assert.Equal(t, tc.ExpectedOutput, stdoutBuf.String())
-			}
-		})
-	}
+	return cases
 }
@@ -19,7 +19,7 @@ var Groups = []kong.Group{
 
 type InputOptions struct {
 	// TODO: stdin support
-	SchemaFiles []string `arg:"" name:"schemas" help:"Path to the GraphQL SDL schema file(s) to read from."`
+	SchemaFiles []string `arg:"" name:"schemas" help:"Path to the GraphQL SDL schema file(s) to read from. Directories are searched recursively for .graphql, .graphqls, and .gql files, and glob patterns (including **) are expanded. Schemas in the JSON format emitted by the json subcommand are also accepted."`
 }
 
 type FilteringOptions struct {
@@ -31,25 +31,151 @@ type IncludeDirectivesOption struct {
 }
 
 type OutputOptions struct {
-	Json bool `name:"json" group:"output" help:"Output results as JSON."`
+	Json bool `name:"json" group:"output" xor:"format" help:"Output results as JSON."`
+}
+
+type SdlOutputOption struct {
+	Sdl bool `name:"sdl" group:"output" xor:"format" help:"Output results as GraphQL SDL, including the definitions of all directives."`
 }
 
 type GraphFilteringOptions struct {
-	From  []string `name:"from" group:"filtering" help:"Only include types reachable from the specified type(s) or field(s). May be specified multiple times to use multiple roots."`
-	Depth int      `name:"depth" group:"filtering" help:"When used with --from, limit the depth of traversal."`
+	From         []string `name:"from" group:"filtering" help:"Only include types reachable from the specified type(s) or field(s). May be specified multiple times to use multiple roots."`
+	Depth        int      `name:"depth" group:"filtering" help:"When used with --from, limit the depth of traversal."`
+	To           []string `name:"to" group:"filtering" help:"Only include types and fields from which the specified type(s) or field(s) are reachable. May be specified multiple times to use multiple targets."`
+	DepthReverse int      `name:"depth-reverse" group:"filtering" help:"When used with --to, limit the depth of reverse traversal."`
 }
 
 func (o GraphFilteringOptions) filterSchema(s *model.Schema) error {
-	if len(o.From) == 0 {
+	if len(o.From) == 0 && len(o.To) == 0 {
 		return nil
 	}
 
-	roots, err := s.ResolveNames(o.From)
+	g, err := o.filterGraph(s, graph.MakeGraph(s))
 	if err != nil {
 		return err
 	}
 
-	g := graph.MakeGraph(s).ReachableFrom(roots, o.Depth)
 	s.Types = g.GetDefinitions()
 	return nil
 }
+
+// filterGraph trims the given graph according to the --from and --to options.
+// If both are given, the result contains only types and fields on paths from the --from roots to the --to targets.
+func (o GraphFilteringOptions) filterGraph(s *model.Schema, g *graph.Graph) (*graph.Graph, error) {
+	if len(o.From) > 0 {
+		roots, err := s.ResolveNames(o.From)
+		if err != nil {
+			return nil, err
+		}
+		g = g.ReachableFrom(roots, o.Depth)
+	}
+
+	if len(o.To) > 0 {
+		targets, err := s.ResolveNames(o.To)
+		if err != nil {
+			return nil, err
+		}
+		g = g.ReachableTo(targets, o.DepthReverse)
+	}
+
+	return g, nil
+}
+
+type DirectiveFilteringOptions struct {
+	WithDirective    []string `name:"with-directive" sep:"none" group:"filtering" help:"Only include types or fields to which the given directive is applied. Arguments to match may be given in SDL notation, like 'auth(role: \"admin\")'. May be specified multiple times, in which case all must match."`
+	WithoutDirective []string `name:"without-directive" sep:"none" group:"filtering" help:"Exclude types or fields to which the given directive is applied. Accepts the same notation as --with-directive. May be specified multiple times."`
+}
+
+// directiveFilter matches directive lists against the --with-directive and --without-directive options.
+type directiveFilter struct {
+	with    []*model.Directive
+	without []*model.Directive
+}
+
+func (o DirectiveFilteringOptions) directiveFilter() (*directiveFilter, error) {
+	f := &directiveFilter{}
+	for _, raw := range o.WithDirective {
+		d, err := model.ParseDirective(raw)
+		if err != nil {
+			return nil, err
+		}
+		f.with = append(f.with, d)
+	}
+	for _, raw := range o.WithoutDirective {
+		d, err := model.ParseDirective(raw)
+		if err != nil {
+			return nil, err
+		}
+		f.without = append(f.without, d)
+	}
+	return f, nil
+}
+
+func (f *directiveFilter) empty() bool {
+	return len(f.with) == 0 && len(f.without) == 0
+}
+
+// matches returns true if the given directives satisfy all of the --with-directive and --without-directive options.
+func (f *directiveFilter) matches(dl model.DirectiveList) bool {
+	return f.matchesWith(dl) && !f.matchesWithout(dl)
+}
+
+func (f *directiveFilter) matchesWith(dl model.DirectiveList) bool {
+	for _, pattern := range f.with {
+		if !dl.Contains(pattern) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *directiveFilter) matchesWithout(dl model.DirectiveList) bool {
+	for _, pattern := range f.without {
+		if dl.Contains(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesField returns true if the given field, defined on def, should be included according to the directive
+// filtering options. Fields are excluded if either they or their host type have a --without-directive directive
+// applied, and otherwise included if either they or their host type match all --with-directive directives.
+func (f *directiveFilter) matchesField(def *model.Definition, field *model.FieldDefinition) bool {
+	if f.matchesWithout(def.Directives) || f.matchesWithout(field.Directives) {
+		return false
+	}
+	return f.matchesWith(def.Directives) || f.matchesWith(field.Directives)
+}
+
+// filterSchema removes types and fields from s according to the directive filtering options.
+// Types with a --without-directive directive applied are removed, and fields are kept according to matchesField.
+// Types which match --with-directive are retained even if they have no remaining fields, and other types are
+// retained only if some of their fields match.
+func (f *directiveFilter) filterSchema(s *model.Schema) {
+	if f.empty() {
+		return
+	}
+
+	result := model.DefinitionMap{}
+	for name, def := range s.Types {
+		if f.matchesWithout(def.Directives) {
+			continue
+		}
+
+		var fields model.FieldDefinitionList
+		for _, field := range def.Fields {
+			if f.matchesField(def, field) {
+				fields = append(fields, field)
+			}
+		}
+
+		if !f.matchesWith(def.Directives) && len(fields) == 0 {
+			continue
+		}
+
+		def.Fields = fields
+		result[name] = def
+	}
+	s.Types = result
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/contract"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+type ContractCmd struct {
+	InputOptions
+	Include    []string `name:"include" sep:"none" group:"filtering" help:"Only include fields which have, or are defined on a type which has, the given directive applied (e.g. 'tag(name: \"public\")'). May be specified multiple times, in which case elements matching any of them are included."`
+	Exclude    []string `name:"exclude" sep:"none" group:"filtering" help:"Exclude types, fields, arguments, input fields, and enum values which have the given directive applied (e.g. 'internal'). May be specified multiple times."`
+	IncludeTag []string `name:"include-tag" group:"filtering" help:"Shorthand for --include 'tag(name: \"<tag>\")'. May be specified multiple times."`
+	ExcludeTag []string `name:"exclude-tag" group:"filtering" help:"Shorthand for --exclude 'tag(name: \"<tag>\")'. May be specified multiple times."`
+	FilteringOptions
+}
+
+func (c ContractCmd) Help() string {
+	return `Produces a variant of a schema (a contract) containing only a subset of its types and fields, selected according to the directives applied to them, and emits it as GraphQL SDL. For example, to produce a public variant of a schema which uses @tag and @internal directives:
+
+  gquil contract --include-tag public --exclude internal schema.graphql
+
+Elements matching an --exclude or --exclude-tag rule are removed, whether they are types, fields, arguments, input fields, or enum values. If any --include or --include-tag rules are given, fields of object and interface types are kept only if they, or the type they're defined on, match one of those rules. Exclusions take precedence over inclusions. Directive patterns use the same syntax as the --with-directive option of 'ls types'.
+
+Removals cascade: fields and arguments referring to removed types, dangling union members and interface implementations, and types which are left empty or become unreachable from the root types are removed too. A type stops implementing an interface if it no longer has all of the interface's fields and arguments. If a non-null field, or a required argument or input field would refer to a removed type, or a default value or directive argument would refer to a removed enum value or input field, an error is returned instead, since removing it would change the meaning of the schema.`
+}
+
+func (c ContractCmd) Run(ctx Context) error {
+	rules, err := c.rules()
+	if err != nil {
+		return err
+	}
+
+	if len(rules.Include) == 0 && len(rules.Exclude) == 0 {
+		return fmt.Errorf("at least one of --include, --exclude, --include-tag, or --exclude-tag must be given")
+	}
+
+	s, err := parseSchemaFromPaths(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	result, err := contract.Apply(s, rules)
+	if err != nil {
+		return err
+	}
+
+	if !c.IncludeBuiltins {
+		astutil.FilterBuiltins(result)
+	}
+
+	f := formatter.NewFormatter(ctx.Stdout)
+	f.FormatSchema(result)
+	return nil
+}
+
+func (c ContractCmd) rules() (contract.Rules, error) {
+	var rules contract.Rules
+
+	parse := func(raw []string, tags []string) ([]*model.Directive, error) {
+		for _, tag := range tags {
+			raw = append(raw, "tag(name: "+strconv.Quote(tag)+")")
+		}
+		var result []*model.Directive
+		for _, r := range raw {
+			d, err := model.ParseDirective(r)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, d)
+		}
+		return result, nil
+	}
+
+	var err error
+	if rules.Include, err = parse(c.Include, c.IncludeTag); err != nil {
+		return rules, err
+	}
+	if rules.Exclude, err = parse(c.Exclude, c.ExcludeTag); err != nil {
+		return rules, err
+	}
+	return rules, nil
+}
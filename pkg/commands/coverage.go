@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"github.com/benweint/gquil/pkg/coverage"
+)
+
+type CoverageCmd struct {
+	OperationInputOptions
+	Kind    string `name:"kind" group:"filtering" enum:"fields,arguments,enum-values" default:"fields" help:"Which kind of schema element to report on. One of fields, arguments, enum-values."`
+	Unused  bool   `name:"unused" group:"filtering" help:"Only list elements which are not used by any operation. Lines are emitted without usage counts, in the same format used by 'ls fields'."`
+	Summary bool   `name:"summary" group:"output" help:"Only print a summary of the coverage percentages for fields, arguments, and enum values."`
+	OutputOptions
+}
+
+func (c CoverageCmd) Help() string {
+	return `Computes which fields, arguments, and enum values of a schema are used by a set of GraphQL operation documents. For example:
+
+  gquil coverage --schema schema.graphql queries/
+
+By default, one line is emitted per field, prefixed with the number of operations which use that field. Use --kind to report on arguments (e.g. 'Query.search(filter:)') or enum values (e.g. 'Color.RED') instead.
+
+To find fields which are not used by any operation, use --unused. In this mode, usage counts are omitted, and lines are emitted in the same format as 'ls fields', making it easy to combine the output with other gquil commands.
+
+The following rules are used to decide whether an element is used:
+
+  * A field is used if it is selected, either directly or via an interface which declares it.
+  * An argument is used if it is provided in a selection.
+  * An input field or enum value is used if it appears in a literal value. If an argument value is provided via a variable, all input fields and enum values reachable from its type are considered used.
+  * All values of an enum are considered used if a field of that enum type is selected.
+
+Use --summary to get the percentage of fields, arguments, and enum values used, or --json to get a JSON representation of the full report, including the names of the operations using each element.`
+}
+
+func (c CoverageCmd) Run(ctx Context) error {
+	rawSchema, err := parseSchemaFromPaths(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	s, err := makeSchemaModel(rawSchema)
+	if err != nil {
+		return err
+	}
+
+	doc, err := c.loadValidOperations(rawSchema)
+	if err != nil {
+		return err
+	}
+
+	report := coverage.Compute(s, doc)
+
+	if c.Json {
+		return ctx.PrintJson(map[string]any{
+			"fields":     report.Fields,
+			"arguments":  report.Arguments,
+			"enumValues": report.EnumValues,
+			"summary":    summarize(report),
+		})
+	}
+
+	if c.Summary {
+		ctx.Printf("fields: %s\n", report.Fields.Summarize())
+		ctx.Printf("arguments: %s\n", report.Arguments.Summarize())
+		ctx.Printf("enum values: %s\n", report.EnumValues.Summarize())
+		return nil
+	}
+
+	usages := report.Fields
+	switch c.Kind {
+	case "arguments":
+		usages = report.Arguments
+	case "enum-values":
+		usages = report.EnumValues
+	}
+
+	for _, u := range usages {
+		if c.Unused && u.Count > 0 {
+			continue
+		}
+
+		line := u.Coordinate
+		if u.TypeName != "" {
+			line += ": " + u.TypeName
+		}
+
+		if c.Unused {
+			ctx.Printf("%s\n", line)
+		} else {
+			ctx.Printf("%d %s\n", u.Count, line)
+		}
+	}
+
+	return nil
+}
+
+func summarize(report *coverage.Report) map[string]coverage.Summary {
+	return map[string]coverage.Summary{
+		"fields":     report.Fields.Summarize(),
+		"arguments":  report.Arguments.Summarize(),
+		"enumValues": report.EnumValues.Summarize(),
+	}
+}
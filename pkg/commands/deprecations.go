@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/deprecations"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type DeprecationsCmd struct {
+	InputOptions
+	Operations []string `name:"operations" short:"o" help:"Path to GraphQL operation document(s) to check for usages of deprecated elements. Directories will be searched recursively for .graphql and .gql files. May be specified multiple times."`
+	Used       bool     `name:"used" group:"filtering" help:"Only list deprecated elements which are used by at least one operation. Requires --operations."`
+	OutputOptions
+}
+
+func (c DeprecationsCmd) Help() string {
+	return `Lists all deprecated fields, arguments, input fields, and enum values in a schema. For example:
+
+  gquil deprecations examples/github.graphql
+
+Each deprecated element is listed along with its deprecation reason. If the reason suggests a replacement (for example, "Use ` + "`newField`" + ` instead"), the replacement is listed too. Elements which are not reachable from any of the schema's root operation types are marked as unreachable.
+
+If operation documents are given with --operations, each deprecated element is also listed with the operations which still use it, using the same rules as the 'coverage' command. Use --used to only list deprecated elements which are still in use.`
+}
+
+func (c DeprecationsCmd) Run(ctx Context) error {
+	if c.Used && len(c.Operations) == 0 {
+		return fmt.Errorf("--used requires --operations")
+	}
+
+	rawSchema, err := parseSchemaFromPaths(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	s, err := makeSchemaModel(rawSchema)
+	if err != nil {
+		return err
+	}
+
+	var doc *ast.QueryDocument
+	if len(c.Operations) > 0 {
+		doc, err = OperationInputOptions{OperationFiles: c.Operations}.loadValidOperations(rawSchema)
+		if err != nil {
+			return err
+		}
+	}
+
+	var results []*deprecations.Deprecation
+	for _, d := range deprecations.Find(s, doc) {
+		if c.Used && len(d.Operations) == 0 {
+			continue
+		}
+		results = append(results, d)
+	}
+
+	if c.Json {
+		if results == nil {
+			results = []*deprecations.Deprecation{}
+		}
+		return ctx.PrintJson(results)
+	}
+
+	for _, d := range results {
+		ctx.Printf("%s: %s\n", d.Coordinate, d.Reason)
+		if d.Replacement != "" {
+			ctx.Printf("  replacement: %s\n", d.Replacement)
+		}
+		if !d.Reachable {
+			ctx.Printf("  unreachable from root types\n")
+		}
+		if doc != nil {
+			if len(d.Operations) > 0 {
+				ctx.Printf("  used by: %s\n", strings.Join(d.Operations, ", "))
+			} else {
+				ctx.Printf("  not used by any operation\n")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/benweint/gquil/pkg/diff"
+)
+
+type DiffCmd struct {
+	OldSchemaFiles []string `name:"old" required:"" help:"Path to the GraphQL SDL schema file(s) representing the old version of the schema. May be specified multiple times."`
+	NewSchemaFiles []string `name:"new" required:"" help:"Path to the GraphQL SDL schema file(s) representing the new version of the schema. May be specified multiple times."`
+	FilteringOptions
+	OutputOptions
+}
+
+func (c DiffCmd) Help() string {
+	return `Compares two versions of a GraphQL schema, and reports added, removed, and changed types, fields, arguments, enum values, union members, interface implementations, and directive definitions. For example:
+
+  gquil diff --old before.graphql --new after.graphql
+
+Each change is classified as one of:
+
+  * BREAKING: some previously-valid operations may become invalid, or return results that existing clients cannot handle (e.g. removing a field, or making an argument non-null).
+  * DANGEROUS: existing operations remain valid, but clients may encounter new runtime behavior (e.g. adding an enum value or union member).
+  * SAFE: the change is backwards-compatible (e.g. adding a new type or a nullable field).
+
+Changes are emitted one per line, with the most severe changes first. Each line includes the criticality, a path identifying the changed schema element, and a human-readable description. You can use --json to get a JSON representation instead.
+
+The command exits with a non-zero status if any breaking changes are found.`
+}
+
+func (c DiffCmd) Run(ctx Context) error {
+	oldSchema, err := loadSchemaModel(c.OldSchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	newSchema, err := loadSchemaModel(c.NewSchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	if !c.IncludeBuiltins {
+		oldSchema.FilterBuiltins()
+		newSchema.FilterBuiltins()
+	}
+
+	changes := diff.Compare(oldSchema, newSchema)
+
+	if c.Json {
+		if err := ctx.PrintJson(changes); err != nil {
+			return err
+		}
+	} else {
+		for _, change := range changes {
+			ctx.Printf("%s\n", change)
+		}
+	}
+
+	if changes.HasBreaking() {
+		return fmt.Errorf("found %d breaking change(s)", changes.Count(diff.Breaking))
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/benweint/gquil/pkg/docs"
+)
+
+type DocsCmd struct {
+	InputOptions
+	OutputDir string `name:"output-dir" short:"o" required:"" help:"Directory to write the generated Markdown pages to. Will be created if it does not exist."`
+	FilteringOptions
+}
+
+func (c *DocsCmd) Help() string {
+	return `Generates a directory of Markdown pages documenting the given GraphQL SDL document(s). For example:
+
+  gquil docs --output-dir docs/api schema.graphql
+
+The output directory will contain an index page (README.md), which lists the fields of the root operation types, along with all types grouped by kind, and all directive definitions. Each type gets its own page under the types/ subdirectory, which includes its description, fields, arguments, default values, deprecations, applied directives, implementations, and the fields and arguments which refer to it.
+
+Existing files in the output directory with the same names as generated pages will be overwritten, but other files will be left alone.`
+}
+
+func (c *DocsCmd) Run(ctx Context) error {
+	s, err := loadSchemaModel(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	if !c.IncludeBuiltins {
+		s.FilterBuiltins()
+	}
+
+	pages, err := docs.Render(s)
+	if err != nil {
+		return err
+	}
+
+	for _, page := range pages {
+		outPath := filepath.Join(c.OutputDir, filepath.FromSlash(page.Path))
+		if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := os.WriteFile(outPath, []byte(page.Content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", outPath, err)
+		}
+	}
+
+	ctx.Printf("Wrote %d pages to %s\n", len(pages), c.OutputDir)
+	return nil
+}
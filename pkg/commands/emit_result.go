@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/benweint/gquil/pkg/execution"
+	"github.com/benweint/gquil/pkg/introspection"
+)
+
+type EmitResultCmd struct {
+	InputOptions
+	SpecVersionOptions
+}
+
+func (c *EmitResultCmd) Help() string {
+	return `Emits the JSON result that a GraphQL server implementing the given schema would return in response to the introspection query emitted by 'gquil introspection query'. This is the reverse of 'gquil introspection generate-sdl', and is useful for tools which consume a schema.json file rather than SDL. For example:
+
+  gquil introspection emit-result schema.graphql >schema.json
+
+The result is wrapped in a top-level 'data' field, as in a GraphQL response. Use --spec-version to control which fields are included: the schema description, isRepeatable, and specifiedByURL fields are only included for october2021.
+
+Note that since the introspection system does not expose applied directives other than @deprecated, they will not be included in the result.`
+}
+
+func (c *EmitResultCmd) Run(ctx Context) error {
+	sv, err := introspection.ParseSpecVersion(c.SpecVersion)
+	if err != nil {
+		return err
+	}
+
+	s, err := parseSchemaFromPaths(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	rsp := execution.Execute(s, execution.Request{
+		Query:         introspection.GetQuery(sv),
+		OperationName: "IntrospectionQuery",
+	})
+	if len(rsp.Errors) > 0 {
+		return fmt.Errorf("failed to execute introspection query: %w", rsp.Errors)
+	}
+
+	return ctx.PrintJson(rsp)
+}
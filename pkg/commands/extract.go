@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/benweint/gquil/pkg/graph"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/benweint/gquil/pkg/subset"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+type ExtractCmd struct {
+	InputOptions
+	Operations []string `name:"operations" short:"o" group:"filtering" help:"Include the types and fields used by the given GraphQL operation document(s). Directories will be searched recursively for .graphql and .gql files. May be specified multiple times."`
+	GraphFilteringOptions
+}
+
+func (c ExtractCmd) Help() string {
+	return `Extracts a subset of a schema, and emits it as valid GraphQL SDL. For example, to extract everything reachable from the Query.repository field:
+
+  gquil extract --from Query.repository examples/github.graphql
+
+The subset to extract is determined by the --from, --depth, --to, and --depth-reverse options, which have the same meaning as for 'ls types', and/or by a set of operation documents given with --operations, in which case only the fields selected by those operations are included. If both are given, the result includes the union of the two.
+
+The result is a self-consistent schema: scalar, enum, and input object types used by included fields, arguments, and directives are included in full, as are the definitions of any directives applied within the subset. Interfaces implemented by included types are kept, along with their included fields. Fields which refer to object, interface, or union types outside of the subset are dropped.
+
+If the query root type is not part of the subset, a Query type with a single placeholder field is synthesized, since every valid schema must have one. If the subset already contains a type named Query, the placeholder is named _Query instead.`
+}
+
+func (c ExtractCmd) Run(ctx Context) error {
+	if len(c.From) == 0 && len(c.To) == 0 && len(c.Operations) == 0 {
+		return fmt.Errorf("at least one of --from, --to, or --operations must be given")
+	}
+
+	rawSchema, err := parseSchemaFromPaths(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	sel := subset.NewSelection()
+
+	if len(c.From) > 0 || len(c.To) > 0 {
+		s, err := makeSchemaModel(rawSchema)
+		if err != nil {
+			return err
+		}
+
+		g, err := c.filterGraph(s, graph.MakeGraph(s))
+		if err != nil {
+			return err
+		}
+
+		selectDefinitions(sel, g.GetDefinitions())
+	}
+
+	if len(c.Operations) > 0 {
+		doc, err := OperationInputOptions{OperationFiles: c.Operations}.loadValidOperations(rawSchema)
+		if err != nil {
+			return err
+		}
+		sel.AddOperations(doc)
+	}
+
+	f := formatter.NewFormatter(ctx.Stdout)
+	f.FormatSchema(subset.Extract(rawSchema, sel))
+	return nil
+}
+
+// selectDefinitions adds the given definitions to sel. Object and interface types are added with only the
+// fields present in defs, and other types are added in full.
+func selectDefinitions(sel *subset.Selection, defs model.DefinitionMap) {
+	for _, def := range defs {
+		switch def.Kind {
+		case ast.Object, ast.Interface:
+			for _, f := range def.Fields {
+				sel.AddField(def.Name, f.Name)
+			}
+		default:
+			sel.AddType(def.Name)
+		}
+	}
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/benweint/gquil/pkg/astutil"
 	"github.com/benweint/gquil/pkg/introspection"
 	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/formatter"
 )
 
 type GenerateSDLCmd struct {
-	Endpoint string   `arg:"" help:"The GraphQL introspection endpoint URL to fetch from."`
+	Endpoint string   `arg:"" optional:"" help:"The GraphQL introspection endpoint URL to fetch from."`
+	FromFile string   `name:"from-file" help:"Read a saved introspection query result from the given file instead of fetching it from an endpoint. Use - to read from stdin."`
 	Headers  []string `name:"header" short:"H" help:"Set custom headers on the introspection request, e.g. for authentication. Format: <key>: <value>. May be specified multiple times. Header values may be read from a file with the syntax @<filename>, e.g. --header @my-headers.txt."`
 	Trace    bool     `name:"trace" help:"Dump the introspection HTTP request and response to stderr for debugging."`
 
@@ -34,27 +36,17 @@ Example:
 
 Note that since GraphQL's introspection schema does not expose information about the application sites of most directives, the generated SDL will lack any applied directives (with the exception of @deprecated, which is exposed via the introspection system).
 
-If your GraphQL endpoint requires authentication or other special headers, you can set custom headers on the issued request using the --header flag.`
-}
+If your GraphQL endpoint requires authentication or other special headers, you can set custom headers on the issued request using the --header flag.
 
-func (c *GenerateSDLCmd) Run(ctx Context) error {
-	sv, err := introspection.ParseSpecVersion(c.SpecVersion)
-	if err != nil {
-		return err
-	}
+To generate SDL from a previously-saved introspection query result without making any network requests, use --from-file instead of passing an endpoint:
 
-	var traceOut io.Writer
-	if c.Trace {
-		traceOut = os.Stderr
-	}
+  gquil introspection generate-sdl --from-file introspection.json
 
-	headers, err := parseHeaders(c.Headers)
-	if err != nil {
-		return fmt.Errorf("failed to parse custom header: %w", err)
-	}
+The file may contain a complete GraphQL response ({"data": {"__schema": ...}}), or just the __schema object.`
+}
 
-	client := introspection.NewClient(c.Endpoint, headers, sv, traceOut)
-	s, err := client.FetchSchemaAst()
+func (c *GenerateSDLCmd) Run(ctx Context) error {
+	s, err := c.loadSchemaAst(ctx)
 	if err != nil {
 		return err
 	}
@@ -75,13 +67,56 @@ func (c *GenerateSDLCmd) Run(ctx Context) error {
 			astutil.FilterBuiltins(s)
 		}
 
-		f := formatter.NewFormatter(os.Stdout)
+		f := formatter.NewFormatter(ctx.Stdout)
 		f.FormatSchema(s)
 	}
 
 	return nil
 }
 
+func (c *GenerateSDLCmd) loadSchemaAst(ctx Context) (*ast.Schema, error) {
+	if c.FromFile != "" {
+		if c.Endpoint != "" {
+			return nil, fmt.Errorf("only one of an endpoint or --from-file may be given")
+		}
+
+		var raw []byte
+		var err error
+		if c.FromFile == "-" {
+			raw, err = io.ReadAll(ctx.Stdin)
+		} else {
+			raw, err = os.ReadFile(c.FromFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read introspection result: %w", err)
+		}
+
+		return introspection.ParseSchemaAst(raw)
+	}
+
+	if c.Endpoint == "" {
+		return nil, fmt.Errorf("either an endpoint or --from-file must be given")
+	}
+
+	sv, err := introspection.ParseSpecVersion(c.SpecVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var traceOut io.Writer
+	if c.Trace {
+		traceOut = os.Stderr
+	}
+
+	headers, err := parseHeaders(c.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse custom header: %w", err)
+	}
+
+	client := introspection.NewClient(c.Endpoint, headers, sv, traceOut)
+	return client.FetchSchemaAst()
+}
+
 func parseHeaders(raw []string) (http.Header, error) {
 	result := http.Header{}
 	for _, rawHeader := range raw {
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,9 +9,12 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/benweint/gquil/pkg/inputs"
 	"github.com/benweint/gquil/pkg/model"
 	"github.com/vektah/gqlparser/v2"
 	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
 )
 
 func loadSchemaModel(paths []string) (*model.Schema, error) {
@@ -19,6 +23,10 @@ func loadSchemaModel(paths []string) (*model.Schema, error) {
 		return nil, err
 	}
 
+	return makeSchemaModel(rawSchema)
+}
+
+func makeSchemaModel(rawSchema *ast.Schema) (*model.Schema, error) {
 	s, err := model.MakeSchema(rawSchema)
 	if err != nil {
 		return nil, err
@@ -31,7 +39,98 @@ func loadSchemaModel(paths []string) (*model.Schema, error) {
 	return s, nil
 }
 
+var schemaFileExtensions = []string{".graphql", ".graphqls", ".gql"}
+
 func parseSchemaFromPaths(paths []string) (*ast.Schema, error) {
+	sources, err := readSchemaSources(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSchemaFromSources(sources)
+}
+
+// readSchemaSources reads the schema files at the given paths, expanding directories and converting any JSON
+// sources to SDL.
+func readSchemaSources(paths []string) ([]*ast.Source, error) {
+	expanded, err := inputs.Expand(paths, schemaFileExtensions)
+	if err != nil {
+		return nil, fmt.Errorf("could not read source SDL: %w", err)
+	}
+
+	sources, err := readSources(expanded, "source SDL")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := convertJsonSources(sources); err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+func parseSchemaFromSources(sources []*ast.Source) (*ast.Schema, error) {
+	// gqlparser stops at the first syntax error, so check each source separately in order to report errors in
+	// all of them at once.
+	var syntaxErrors []string
+	for _, source := range sources {
+		if _, err := parser.ParseSchema(source); err != nil {
+			syntaxErrors = append(syntaxErrors, err.Error())
+		}
+	}
+	if len(syntaxErrors) == 1 {
+		return nil, fmt.Errorf("failed to parse source SDL: %s", syntaxErrors[0])
+	}
+	if len(syntaxErrors) > 1 {
+		return nil, fmt.Errorf("failed to parse source SDL, found errors in %d files:\n%s", len(syntaxErrors), strings.Join(syntaxErrors, "\n"))
+	}
+
+	schema, err := gqlparser.LoadSchema(sources...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source SDL: %w", err)
+	}
+
+	return schema, nil
+}
+
+// convertJsonSources replaces the contents of any of the given sources which hold a schema in gquil's JSON
+// format (as emitted by the json subcommand) with the equivalent GraphQL SDL, so that they can be loaded
+// alongside regular SDL sources. JSON sources are recognized by their first non-whitespace character, since
+// a GraphQL schema document can't begin with '{'.
+func convertJsonSources(sources []*ast.Source) error {
+	for _, source := range sources {
+		if !strings.HasPrefix(strings.TrimSpace(source.Input), "{") {
+			continue
+		}
+
+		var s model.Schema
+		if err := json.Unmarshal([]byte(source.Input), &s); err != nil {
+			return fmt.Errorf("failed to parse JSON schema from %s: %w", source.Name, err)
+		}
+		if len(s.Types) == 0 {
+			return fmt.Errorf("failed to parse JSON schema from %s: no types found (expected the format emitted by 'gquil json')", source.Name)
+		}
+
+		// Built-in types and directives will be added back when the SDL is loaded.
+		s.FilterBuiltins()
+		converted, err := s.ToAst()
+		if err != nil {
+			return fmt.Errorf("failed to convert JSON schema from %s: %w", source.Name, err)
+		}
+
+		var buf bytes.Buffer
+		formatter.NewFormatter(&buf).FormatSchema(converted)
+		source.Input = buf.String()
+		// Positions in errors raised while loading the schema refer to the generated SDL, not the JSON input.
+		source.Name += " (converted from JSON)"
+	}
+	return nil
+}
+
+// readSources reads the contents of each of the given paths into an *ast.Source.
+// The path '-' is treated as referring to stdin. The given description is used in error messages.
+func readSources(paths []string, description string) ([]*ast.Source, error) {
 	var sources []*ast.Source
 	for _, path := range paths {
 		var raw []byte
@@ -43,7 +142,7 @@ func parseSchemaFromPaths(paths []string) (*ast.Schema, error) {
 			raw, err = os.ReadFile(path)
 		}
 		if err != nil {
-			return nil, fmt.Errorf("could not read source SDL from %s: %w", path, err)
+			return nil, fmt.Errorf("could not read %s from %s: %w", description, path, err)
 		}
 
 		source := ast.Source{
@@ -52,12 +151,7 @@ func parseSchemaFromPaths(paths []string) (*ast.Schema, error) {
 		}
 		sources = append(sources, &source)
 	}
-	schema, err := gqlparser.LoadSchema(sources...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse source SDL: %w", err)
-	}
-
-	return schema, nil
+	return sources, nil
 }
 
 func formatArgumentDefinitionList(al model.ArgumentDefinitionList) string {
@@ -73,23 +167,6 @@ func formatArgumentDefinitionList(al model.ArgumentDefinitionList) string {
 	return "(" + strings.Join(formattedArgs, ", ") + ")"
 }
 
-func formatArgumentList(al model.ArgumentList) (string, error) {
-	if len(al) == 0 {
-		return "", nil
-	}
-
-	var formattedArgs []string
-	for _, arg := range al {
-		formattedValue, err := formatValue(arg.Value)
-		if err != nil {
-			return "", err
-		}
-		formatted := fmt.Sprintf("%s: %s", arg.Name, formattedValue)
-		formattedArgs = append(formattedArgs, formatted)
-	}
-	return "(" + strings.Join(formattedArgs, ", ") + ")", nil
-}
-
 func formatDirectiveList(dl model.DirectiveList) (string, error) {
 	if len(dl) == 0 {
 		return "", nil
@@ -97,18 +174,24 @@ func formatDirectiveList(dl model.DirectiveList) (string, error) {
 
 	var formattedDirectives []string
 	for _, d := range dl {
-		formattedArgs, err := formatArgumentList(d.Arguments)
+		formatted, err := d.Format()
 		if err != nil {
 			return "", err
 		}
-		formatted := fmt.Sprintf("@%s%s", d.Name, formattedArgs)
 		formattedDirectives = append(formattedDirectives, formatted)
 	}
 
 	return " " + strings.Join(formattedDirectives, " "), nil
 }
 
-func formatValue(v model.Value) (string, error) {
-	raw, err := json.Marshal(v)
-	return string(raw), err
+// printSchemaSdl prints the given schema model as GraphQL SDL.
+func printSchemaSdl(ctx Context, s *model.Schema) error {
+	converted, err := s.ToAst()
+	if err != nil {
+		return err
+	}
+
+	f := formatter.NewFormatter(ctx.Stdout)
+	f.FormatSchema(converted)
+	return nil
 }
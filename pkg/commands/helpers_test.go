@@ -0,0 +1,12 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSchemaErrorsNameConvertedJsonSources(t *testing.T) {
+	_, err := parseSchemaFromPaths([]string{"testdata/undefined_reference.json"})
+	assert.EqualError(t, err, "failed to parse source SDL: testdata/undefined_reference.json (converted from JSON):2: Undefined type User.")
+}
@@ -7,6 +7,7 @@ import (
 type IntrospectionCmd struct {
 	GenerateSDL GenerateSDLCmd `cmd:"" help:"Generate GraphQL SDL from a GraphQL introspection endpoint over HTTP(S)."`
 	Query       EmitQueryCmd   `cmd:"" help:"Emit the GraphQL query used for introspection."`
+	EmitResult  EmitResultCmd  `cmd:"" help:"Emit the introspection query result for the given GraphQL SDL document(s)."`
 }
 
 type SpecVersionOptions struct {
@@ -1,9 +1,16 @@
 package commands
 
+import (
+	"github.com/benweint/gquil/pkg/subset"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
 type JsonCmd struct {
 	InputOptions
 	FilteringOptions
 	GraphFilteringOptions
+	DirectiveFilteringOptions
+	SdlOutputOption
 }
 
 func (c *JsonCmd) Help() string {
@@ -14,22 +21,51 @@ Unlike the introspection types in the GraphQL spec, the JSON output format inclu
 The JSON format for fields and arguments also adds several convenience fields which are useful when processing the output:
 
   * underlyingTypeName: the underlying named type of the field, after unwrapping list and non-null wrapping types. For example, a field of type '[String!]' would have an underlyingTypeName of 'String')
-  * typeName: the type of the field, represented as a string in GraphQL SDL notation (for example: '[String!]!')`
+  * typeName: the type of the field, represented as a string in GraphQL SDL notation (for example: '[String!]!')
+
+You can use --with-directive and --without-directive to restrict the output to the types and fields where a given directive is (or is not) applied. With --with-directive, types to which the directive is applied are included in full, and other types are included only with those of their fields to which the directive is applied. When combined with --from or --to, the roots and targets are resolved against the full schema, and the directive filters are applied to the result.
+
+Use --sdl to print the (possibly filtered) schema as GraphQL SDL instead of JSON. This is useful for passing the result of --from or --to filtering on to other tools which accept SDL. When the schema is filtered, the result is made self-consistent in the same way as by the extract subcommand: scalar, enum, input object, and interface types needed by the remaining fields are included, fields and union members referring to types which were filtered out are dropped, and only the directive definitions used within the result are emitted.`
 }
 
 func (c *JsonCmd) Run(ctx Context) error {
-	s, err := loadSchemaModel(c.SchemaFiles)
+	rawSchema, err := parseSchemaFromPaths(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	s, err := makeSchemaModel(rawSchema)
 	if err != nil {
 		return err
 	}
 
+	directiveFilter, err := c.directiveFilter()
+	if err != nil {
+		return err
+	}
+
+	// --from and --to are resolved against the full schema, before any types are removed by the directive filter.
 	if err = c.filterSchema(s); err != nil {
 		return err
 	}
+	directiveFilter.filterSchema(s)
 
 	if !c.IncludeBuiltins {
 		s.FilterBuiltins()
 	}
 
+	if c.Sdl {
+		if len(c.From) == 0 && len(c.To) == 0 && directiveFilter.empty() {
+			return printSchemaSdl(ctx, s)
+		}
+
+		// Filtering may leave behind references to types which were removed, so only emit the remaining types
+		// and fields which can form a valid schema on their own.
+		sel := subset.NewSelection()
+		selectDefinitions(sel, s.Types)
+		formatter.NewFormatter(ctx.Stdout).FormatSchema(subset.Extract(rawSchema, sel))
+		return nil
+	}
+
 	return ctx.PrintJson(s)
 }
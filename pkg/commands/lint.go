@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/lint"
+)
+
+type LintCmd struct {
+	InputOptions
+	Config  string   `name:"config" help:"Path to a YAML file used to configure lint rules."`
+	Enable  []string `name:"enable" help:"Enable the named rule, overriding the config file. May be specified multiple times."`
+	Disable []string `name:"disable" help:"Disable the named rule, overriding the config file. May be specified multiple times."`
+	OutputOptions
+	Sarif bool `name:"sarif" group:"output" xor:"format" help:"Output results as a SARIF log, suitable for code scanning tools."`
+}
+
+func (c LintCmd) Help() string {
+	var ruleDescriptions []string
+	for _, rule := range lint.DefaultRules() {
+		ruleDescriptions = append(ruleDescriptions, fmt.Sprintf("  * %s (%s): %s", rule.Name, rule.DefaultSeverity, rule.Description))
+	}
+
+	return `Checks the given schema against a set of named lint rules, and reports violations along with their source locations. Built-in types and directives are never checked.
+
+The following rules are available, and all are enabled by default:
+
+` + strings.Join(ruleDescriptions, "\n") + `
+
+Rules can be individually enabled or disabled using --enable and --disable, or configured via a YAML file passed with --config, like this:
+
+  rules:
+    type-descriptions:
+      enabled: false
+    input-type-suffix:
+      severity: warning
+      options:
+        suffix: Input
+
+Violations are reported one per line by default. Use --json for a JSON list of violations, or --sarif for a SARIF log suitable for code scanning tools.
+
+The command exits with a non-zero status if any violations with 'error' severity are found.`
+}
+
+func (c LintCmd) Run(ctx Context) error {
+	var config lint.Config
+	if c.Config != "" {
+		var err error
+		if config, err = lint.LoadConfig(c.Config); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range c.Enable {
+		config.SetEnabled(name, true)
+	}
+	for _, name := range c.Disable {
+		config.SetEnabled(name, false)
+	}
+
+	linter, err := lint.NewLinter(lint.DefaultRules(), config)
+	if err != nil {
+		return err
+	}
+
+	s, err := loadSchemaModel(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	s.FilterBuiltins()
+
+	violations := linter.Run(s)
+
+	switch {
+	case c.Json:
+		err = ctx.PrintJson(violations)
+	case c.Sarif:
+		err = ctx.PrintJson(lint.ToSARIF(linter.Rules(), violations))
+	default:
+		for _, v := range violations {
+			ctx.Printf("%s\n", v)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if n := violations.Count(lint.SeverityError); n > 0 {
+		return fmt.Errorf("found %d lint error(s)", n)
+	}
+
+	return nil
+}
@@ -3,5 +3,8 @@ package commands
 type LsCmd struct {
 	Types      LsTypesCmd      `cmd:"" help:"List types in the given schema(s)."`
 	Fields     LsFieldsCmd     `cmd:"" help:"List fields in the given schema(s)."`
+	EnumValues LsEnumValuesCmd `cmd:"" help:"List enum values in the given schema(s)."`
+	Arguments  LsArgumentsCmd  `cmd:"" help:"List field and directive arguments in the given schema(s)."`
 	Directives LsDirectivesCmd `cmd:"" help:"List directive definitions in the given schema(s)."`
+	Cycles     LsCyclesCmd     `cmd:"" help:"List cycles or strongly connected components in the type graph of the given schema(s)."`
 }
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/model"
+)
+
+type LsArgumentsCmd struct {
+	InputOptions
+	OfType         string `name:"of-type" group:"filtering" help:"Only include arguments of the specified type. List and non-null types will be treated as being of their underlying wrapped type for the purposes of this filtering."`
+	Named          string `name:"named" group:"filtering" help:"Only include arguments with the given name (matched against the argument name only)."`
+	Required       bool   `name:"required" group:"filtering" help:"Only include required arguments (those with a non-null type and no default value)."`
+	WithDefault    bool   `name:"with-default" group:"filtering" help:"Only include arguments which have a default value."`
+	WithoutDefault bool   `name:"without-default" group:"filtering" help:"Only include arguments which have no default value."`
+	IncludeDirectivesOption
+	OutputOptions
+	FilteringOptions
+}
+
+func (c LsArgumentsCmd) Help() string {
+	return `Lists the arguments of all fields and directive definitions. Field arguments are identified as <type>.<field>(<arg>:), and directive arguments as @<directive>(<arg>:), matching the notation used by the other subcommands. Arguments are emitted in sorted order by these identifiers, followed by their type and default value (in GraphQL syntax), if any, in the form <type>.<field>(<arg>:): <arg type> = <default>.
+
+You can use the --of-type, --named, --required, --with-default, and --without-default flags to filter the set of returned arguments. For example, to find all 'first' arguments without a default value:
+
+  gquil ls arguments --named first --without-default examples/github.graphql
+
+Directives applied to arguments are not included in the output by default, but can be added with --include-directives. You can also use --json for a JSON output format, in which argument names will be replaced by the identifiers described above.`
+}
+
+func (c LsArgumentsCmd) Run(ctx Context) error {
+	if c.WithDefault && c.WithoutDefault {
+		return fmt.Errorf("only one of --with-default or --without-default may be given")
+	}
+
+	s, err := loadSchemaModel(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	if !c.IncludeBuiltins {
+		s.FilterBuiltins()
+	}
+
+	var args model.ArgumentDefinitionList
+	addArgs := func(prefix string, defs model.ArgumentDefinitionList) {
+		for _, arg := range defs {
+			if c.includeArgument(arg) {
+				qualified := *arg
+				qualified.Name = astutil.ArgumentCoordinate(prefix, arg.Name)
+				args = append(args, &qualified)
+			}
+		}
+	}
+
+	for _, t := range s.Types {
+		for _, f := range t.Fields {
+			addArgs(t.Name+"."+f.Name, f.Arguments)
+		}
+	}
+	for _, d := range s.Directives {
+		addArgs("@"+d.Name, d.Arguments)
+	}
+	args.Sort()
+
+	if c.Json {
+		return ctx.PrintJson(args)
+	}
+
+	for _, arg := range args {
+		defaultValue := ""
+		if arg.DefaultValue != nil {
+			formatted, err := model.FormatValue(arg.DefaultValue)
+			if err != nil {
+				return err
+			}
+			defaultValue = " = " + formatted
+		}
+		directives := ""
+		if c.IncludeDirectives {
+			directives, err = formatDirectiveList(arg.Directives)
+			if err != nil {
+				return err
+			}
+		}
+		ctx.Printf("%s: %s%s%s\n", arg.Name, arg.Type, defaultValue, directives)
+	}
+
+	return nil
+}
+
+func (c LsArgumentsCmd) includeArgument(arg *model.ArgumentDefinition) bool {
+	hasDefault := arg.DefaultValue != nil
+	if c.OfType != "" && c.OfType != arg.Type.Unwrap().Name {
+		return false
+	}
+	if c.Named != "" && c.Named != arg.Name {
+		return false
+	}
+	if c.Required && (arg.Type.Kind != model.NonNullKind || hasDefault) {
+		return false
+	}
+	if c.WithDefault && !hasDefault {
+		return false
+	}
+	if c.WithoutDefault && hasDefault {
+		return false
+	}
+	return true
+}
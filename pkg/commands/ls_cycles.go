@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/benweint/gquil/pkg/graph"
+)
+
+type LsCyclesCmd struct {
+	InputOptions
+	SCC                bool `name:"scc" group:"output" help:"List strongly connected components rather than individual cycles."`
+	MaxLength          int  `name:"max-length" default:"3" group:"filtering" help:"Only include cycles with at most this many steps. Use 0 to list cycles of any length."`
+	OutputFieldsOnly   bool `name:"output-fields-only" group:"filtering" help:"Only consider fields of output types which take no arguments."`
+	InterfacesAsUnions bool `name:"interfaces-as-unions" help:"Treat interfaces as unions rather than objects for the purposes of graph construction."`
+	OutputOptions
+	FilteringOptions
+	GraphFilteringOptions
+}
+
+func (c LsCyclesCmd) Help() string {
+	return `Cycles are paths through the schema graph which start and end at the same type, without visiting any other type more than once. They are emitted one per line in the same format used by the paths subcommand, starting from the lexically-first type in each cycle, and sorted by length. For example:
+
+  User.repositories -> Repository.owner
+
+The number of cycles in a large schema can be enormous, so only cycles with at most 3 steps are listed by default. Use --max-length to change this limit, or --max-length 0 to list cycles of any length, which may take a very long time for large schemas. --scc is a cheaper way to find all of the types involved in cycles.
+
+Use --scc to instead list strongly connected components: sets of types which are all reachable from one another. Each component is emitted on one line as a space-separated list of type names, with the largest components listed first. Any component may be traversed an unbounded number of times by a single query.
+
+By default, edges from fields to their argument types, and between input object types are included. To only consider the fields which can actually be nested within a query without providing arguments, use --output-fields-only.
+
+You can also filter by graph reachability using the --from and --depth options (or in reverse, using --to and --depth-reverse), see the help for these flags for details.`
+}
+
+func (c LsCyclesCmd) Run(ctx Context) error {
+	s, err := loadSchemaModel(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	if err = c.filterSchema(s); err != nil {
+		return err
+	}
+
+	if !c.IncludeBuiltins {
+		s.FilterBuiltins()
+	}
+
+	var opts []graph.GraphOption
+	if c.InterfacesAsUnions {
+		opts = append(opts, graph.WithInterfacesAsUnions())
+	}
+	if c.OutputFieldsOnly {
+		opts = append(opts, graph.WithOutputFieldsOnly())
+	}
+	g := graph.MakeGraph(s, opts...)
+
+	if c.SCC {
+		components := g.StronglyConnectedComponents()
+		if c.Json {
+			if components == nil {
+				components = [][]string{}
+			}
+			return ctx.PrintJson(components)
+		}
+		for _, component := range components {
+			ctx.Printf("%s\n", strings.Join(component, " "))
+		}
+		return nil
+	}
+
+	cycles := g.Cycles(c.MaxLength)
+	if c.Json {
+		if cycles == nil {
+			cycles = []graph.Path{}
+		}
+		return ctx.PrintJson(cycles)
+	}
+	for _, cycle := range cycles {
+		ctx.Printf("%s\n", cycle)
+	}
+
+	return nil
+}
@@ -2,13 +2,20 @@ package commands
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/benweint/gquil/pkg/astutil"
 	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
 )
 
 type LsDirectivesCmd struct {
 	InputOptions
+	Usages bool `name:"usages" xor:"mode" help:"List every site at which each directive is applied, rather than the directive definitions."`
+	Counts bool `name:"counts" xor:"mode" help:"List the number of times each directive is applied."`
+	Unused bool `name:"unused" xor:"mode" help:"Only list directives which are defined but never applied."`
 	FilteringOptions
 	OutputOptions
 }
@@ -16,11 +23,42 @@ type LsDirectivesCmd struct {
 func (c LsDirectivesCmd) Help() string {
 	return `List all directive definitions in the given GraphQL SDL file(s).
 
-By default, directives are emitted with their argument definitions and valid application locations, in a format that mirrors the SDL for defining them. You can emit JSON representations of them instead with the --json flag.`
+By default, directives are emitted with their argument definitions and valid application locations, in a format that mirrors the SDL for defining them. You can emit JSON representations of them instead with the --json flag.
+
+Use --usages to instead list every site at which a directive is applied (the schema definition, types, fields, arguments of fields and directives, enum values, and input fields), along with the arguments passed to it at that site. For example:
+
+  gquil ls directives --usages examples/github.graphql
+
+Use --counts to list the number of times each directive is applied (including directives which are never applied), or --unused to list only the definitions of directives which are never applied.
+
+Built-in directives like @deprecated are omitted unless --include-builtins is given.`
+}
+
+// directiveUsage describes a single application of a directive within a schema.
+type directiveUsage struct {
+	Directive  string                `json:"directive"`
+	Coordinate string                `json:"coordinate"`
+	Location   ast.DirectiveLocation `json:"location"`
+	Arguments  model.ArgumentList    `json:"arguments,omitempty"`
+}
+
+type directiveCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
 }
 
 func (c LsDirectivesCmd) Run(ctx Context) error {
-	s, err := loadSchemaModel(c.SchemaFiles)
+	sources, err := readSchemaSources(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	rawSchema, err := parseSchemaFromSources(sources)
+	if err != nil {
+		return err
+	}
+
+	s, err := makeSchemaModel(rawSchema)
 	if err != nil {
 		return err
 	}
@@ -29,17 +67,184 @@ func (c LsDirectivesCmd) Run(ctx Context) error {
 		s.FilterBuiltins()
 	}
 
+	// gqlparser doesn't retain the directives applied to the schema definition, so recover them from the
+	// sources directly.
+	schemaDirectives, err := schemaDefinitionDirectives(sources)
+	if err != nil {
+		return err
+	}
+
+	directives := s.Directives
+
+	switch {
+	case c.Usages:
+		return c.printUsages(ctx, directiveUsages(s, schemaDirectives, directives))
+	case c.Counts:
+		return c.printCounts(ctx, directiveCounts(directives, directiveUsages(s, schemaDirectives, directives)))
+	case c.Unused:
+		var unused model.DirectiveDefinitionList
+		for i, dc := range directiveCounts(directives, directiveUsages(s, schemaDirectives, directives)) {
+			if dc.Count == 0 {
+				unused = append(unused, directives[i])
+			}
+		}
+		directives = unused
+	}
+
 	if c.Json {
-		return ctx.PrintJson(s.Directives)
+		if directives == nil {
+			directives = model.DirectiveDefinitionList{}
+		}
+		return ctx.PrintJson(directives)
 	}
 
-	for _, directive := range s.Directives {
+	for _, directive := range directives {
 		ctx.Printf("%s\n", formatDirectiveDefinition(directive))
 	}
 
 	return nil
 }
 
+func (c LsDirectivesCmd) printUsages(ctx Context, usages []directiveUsage) error {
+	if c.Json {
+		return ctx.PrintJson(usages)
+	}
+
+	for _, u := range usages {
+		formattedArgs, err := u.Arguments.Format()
+		if err != nil {
+			return err
+		}
+		ctx.Printf("@%s%s %s\n", u.Directive, formattedArgs, u.Coordinate)
+	}
+
+	return nil
+}
+
+func (c LsDirectivesCmd) printCounts(ctx Context, counts []directiveCount) error {
+	if c.Json {
+		return ctx.PrintJson(counts)
+	}
+
+	for _, dc := range counts {
+		ctx.Printf("%d @%s\n", dc.Count, dc.Name)
+	}
+
+	return nil
+}
+
+// schemaDefinitionDirectives returns the directives applied to the schema definition and any schema extensions
+// in the given sources.
+func schemaDefinitionDirectives(sources []*ast.Source) (model.DirectiveList, error) {
+	var applied ast.DirectiveList
+	for _, source := range sources {
+		doc, err := parser.ParseSchema(source)
+		if err != nil {
+			return nil, err
+		}
+		for _, sd := range doc.Schema {
+			applied = append(applied, sd.Directives...)
+		}
+		for _, sd := range doc.SchemaExtension {
+			applied = append(applied, sd.Directives...)
+		}
+	}
+	return model.MakeDirectiveList(applied)
+}
+
+// directiveUsages returns every application of the given directives within the schema, sorted by directive
+// name and then coordinate. Applications of directives which are not in the given list are omitted.
+func directiveUsages(s *model.Schema, schemaDirectives model.DirectiveList, directives model.DirectiveDefinitionList) []directiveUsage {
+	defined := map[string]bool{}
+	for _, d := range directives {
+		defined[d.Name] = true
+	}
+
+	usages := []directiveUsage{}
+	add := func(dl model.DirectiveList, coordinate string, location ast.DirectiveLocation) {
+		for _, d := range dl {
+			if !defined[d.Name] {
+				continue
+			}
+			usages = append(usages, directiveUsage{
+				Directive:  d.Name,
+				Coordinate: coordinate,
+				Location:   location,
+				Arguments:  d.Arguments,
+			})
+		}
+	}
+
+	add(schemaDirectives, "schema", ast.LocationSchema)
+
+	for _, dd := range s.Directives {
+		for _, arg := range dd.Arguments {
+			add(arg.Directives, astutil.ArgumentCoordinate("@"+dd.Name, arg.Name), ast.LocationArgumentDefinition)
+		}
+	}
+
+	for _, def := range s.Types.ToSortedList() {
+		add(def.Directives, def.Name, typeDirectiveLocation(def.Kind))
+
+		for _, f := range def.Fields {
+			fieldCoordinate := def.Name + "." + f.Name
+			if def.Kind == ast.InputObject {
+				add(f.Directives, fieldCoordinate, ast.LocationInputFieldDefinition)
+				continue
+			}
+			add(f.Directives, fieldCoordinate, ast.LocationFieldDefinition)
+			for _, arg := range f.Arguments {
+				add(arg.Directives, astutil.ArgumentCoordinate(fieldCoordinate, arg.Name), ast.LocationArgumentDefinition)
+			}
+		}
+
+		for _, ev := range def.EnumValues {
+			add(ev.Directives, def.Name+"."+ev.Name, ast.LocationEnumValue)
+		}
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		if usages[i].Directive != usages[j].Directive {
+			return usages[i].Directive < usages[j].Directive
+		}
+		return usages[i].Coordinate < usages[j].Coordinate
+	})
+
+	return usages
+}
+
+// directiveCounts returns the number of usages of each of the given directives, in the same order as the
+// given directive list.
+func directiveCounts(directives model.DirectiveDefinitionList, usages []directiveUsage) []directiveCount {
+	counts := map[string]int{}
+	for _, u := range usages {
+		counts[u.Directive]++
+	}
+
+	result := []directiveCount{}
+	for _, d := range directives {
+		result = append(result, directiveCount{Name: d.Name, Count: counts[d.Name]})
+	}
+	return result
+}
+
+func typeDirectiveLocation(kind ast.DefinitionKind) ast.DirectiveLocation {
+	switch kind {
+	case ast.Scalar:
+		return ast.LocationScalar
+	case ast.Interface:
+		return ast.LocationInterface
+	case ast.Union:
+		return ast.LocationUnion
+	case ast.Enum:
+		return ast.LocationEnum
+	case ast.InputObject:
+		return ast.LocationInputObject
+	default:
+		return ast.LocationObject
+	}
+}
+
 func formatDirectiveDefinition(d *model.DirectiveDefinition) string {
 	var locations []string
 	for _, kind := range d.Locations {
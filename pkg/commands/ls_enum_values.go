@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type LsEnumValuesCmd struct {
+	InputOptions
+	OnType     string `name:"on-type" group:"filtering" help:"Only include values of the specified enum type."`
+	Deprecated bool   `name:"deprecated" group:"filtering" help:"Only include enum values which are marked with @deprecated."`
+	IncludeDirectivesOption
+	OutputOptions
+	FilteringOptions
+	GraphFilteringOptions
+}
+
+func (c LsEnumValuesCmd) Help() string {
+	return `Enum values are identified as <enum>.<value>, where <enum> is the name of the enum type on which they are defined, and are emitted in sorted order by these identifiers. For example:
+
+  gquil ls enum-values --on-type RepositoryPrivacy examples/github.graphql
+
+You can use --on-type to list only the values of a single enum, and --deprecated to list only deprecated values. You can also filter by graph reachability using the --from and --depth options (or in reverse, using --to and --depth-reverse), see the help for these flags for details.
+
+Directives are not included in the output by default, but can be added with --include-directives. You can also use --json for a JSON output format. The JSON output format matches the one used by the json subcommand, with the exception that value names will include the enum type as a prefix (e.g. 'Color.RED' instead of just 'RED').`
+}
+
+func (c LsEnumValuesCmd) Run(ctx Context) error {
+	s, err := loadSchemaModel(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	if err = c.filterSchema(s); err != nil {
+		return err
+	}
+
+	if !c.IncludeBuiltins {
+		s.FilterBuiltins()
+	}
+
+	var values model.EnumValueList
+	for _, t := range s.Types {
+		if t.Kind != ast.Enum {
+			continue
+		}
+		if c.OnType != "" && c.OnType != t.Name {
+			continue
+		}
+		for _, ev := range t.EnumValues {
+			if c.Deprecated && ev.Directives.Named("deprecated") == nil {
+				continue
+			}
+			ev.Name = t.Name + "." + ev.Name
+			values = append(values, ev)
+		}
+	}
+	values.Sort()
+
+	if c.Json {
+		return ctx.PrintJson(values)
+	}
+
+	for _, ev := range values {
+		directives := ""
+		if c.IncludeDirectives {
+			directives, err = formatDirectiveList(ev.Directives)
+			if err != nil {
+				return err
+			}
+		}
+		ctx.Printf("%s%s\n", ev.Name, directives)
+	}
+
+	return nil
+}
@@ -15,16 +15,20 @@ type LsFieldsCmd struct {
 	IncludeArgs   bool   `name:"include-args" group:"output" help:"Include argument definitions in human-readable output. Has no effect with --json."`
 	IncludeDirectivesOption
 	OutputOptions
+	SdlOutputOption
 	FilteringOptions
 	GraphFilteringOptions
+	DirectiveFilteringOptions
 }
 
 func (c LsFieldsCmd) Help() string {
 	return `Fields are identified as <type>.<fieldname>, where <type> is the host type on which they are defined, and are emitted in sorted order by these identifiers.
 
-You can use the --on-type, --of-type, --returning-type, and --named arguments to filter the set of returned fields. You can also filter by graph reachability using the --from and --depth options, see the help for these flags for details.
+You can use the --on-type, --of-type, --returning-type, and --named arguments to filter the set of returned fields. To filter by the directives applied to fields, use --with-directive and --without-directive, for example '--with-directive deprecated'. As with the json and viz subcommands, a directive applied to a type also applies to all of its fields, so '--with-directive internal' includes every field of a type marked @internal. You can also filter by graph reachability using the --from and --depth options (or in reverse, using --to and --depth-reverse), see the help for these flags for details.
 
-Field arguments and directives are not included in the output by default (only names and types), but can be added with --include-args and --include-directives, respectivesly. You can also use --json for a JSON output format. The JSON output format matches the one used by the json subcommand, with the exception that field names will include the host type as a prefix (e.g. 'Query.search' instead of just 'search').`
+Field arguments and directives are not included in the output by default (only names and types), but can be added with --include-args and --include-directives, respectivesly. You can also use --json for a JSON output format. The JSON output format matches the one used by the json subcommand, with the exception that field names will include the host type as a prefix (e.g. 'Query.search' instead of just 'search').
+
+With --sdl, the listed fields are printed as GraphQL SDL, grouped under their host types, along with the definitions of all directives.`
 }
 
 func (c LsFieldsCmd) Run(ctx Context) error {
@@ -37,11 +41,17 @@ func (c LsFieldsCmd) Run(ctx Context) error {
 		return err
 	}
 
+	directiveFilter, err := c.directiveFilter()
+	if err != nil {
+		return err
+	}
+
 	if !c.IncludeBuiltins {
 		s.FilterBuiltins()
 	}
 
 	var fields model.FieldDefinitionList
+	sdlTypes := model.DefinitionMap{}
 	for _, t := range s.Types {
 		if c.OnType != "" && c.OnType != t.Name {
 			continue
@@ -56,12 +66,29 @@ func (c LsFieldsCmd) Run(ctx Context) error {
 			if c.Named != "" && c.Named != f.Name {
 				continue
 			}
+			if !directiveFilter.matchesField(t, f) {
+				continue
+			}
+			if c.Sdl {
+				addSdlField(sdlTypes, t, f)
+				continue
+			}
 			f.Name = t.Name + "." + f.Name
 			fields = append(fields, f)
 		}
 	}
 	fields.Sort()
 
+	if c.Sdl {
+		return printSchemaSdl(ctx, &model.Schema{
+			Types:                sdlTypes,
+			QueryTypeName:        s.QueryTypeName,
+			MutationTypeName:     s.MutationTypeName,
+			SubscriptionTypeName: s.SubscriptionTypeName,
+			Directives:           s.Directives,
+		})
+	}
+
 	if c.Json {
 		return ctx.PrintJson(fields)
 	}
@@ -97,3 +124,15 @@ func fieldMightReturn(s *model.Schema, field *model.FieldDefinition, typeName st
 
 	return false
 }
+
+// addSdlField adds the given field to a copy of its host type within types, creating the copy if needed.
+func addSdlField(types model.DefinitionMap, host *model.Definition, f *model.FieldDefinition) {
+	def, ok := types[host.Name]
+	if !ok {
+		copied := *host
+		copied.Fields = nil
+		def = &copied
+		types[host.Name] = def
+	}
+	def.Fields = append(def.Fields, f)
+}
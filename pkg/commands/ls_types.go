@@ -16,7 +16,9 @@ type LsTypesCmd struct {
 	IncludeDirectivesOption
 	FilteringOptions
 	OutputOptions
+	SdlOutputOption
 	GraphFilteringOptions
+	DirectiveFilteringOptions
 }
 
 func (c LsTypesCmd) Help() string {
@@ -24,9 +26,11 @@ func (c LsTypesCmd) Help() string {
 
   gquil ls types --kind interface examples/github.graphql
 
-You can also filter types based on their membership in a union type (--member-of), or based on whether they implement a specified interface (--implements). You can also filter by graph reachability using the --from and --depth options, see the help for these flags for details.
+You can also filter types based on their membership in a union type (--member-of), based on whether they implement a specified interface (--implements), or based on the directives applied to them (--with-directive and --without-directive). You can also filter by graph reachability using the --from and --depth options (or in reverse, using --to and --depth-reverse), see the help for these flags for details.
 
 Directives are not included in the output by default, but can be added with --include-directives. You can also use --json for a JSON output format. The JSON output format matches the one used by the json subcommand.
+
+With --sdl, the listed types are printed as GraphQL SDL, along with the definitions of all directives. Note that the output may refer to types which were not listed.
 `
 }
 
@@ -40,6 +44,11 @@ func (c LsTypesCmd) Run(ctx Context) error {
 		return err
 	}
 
+	directiveFilter, err := c.directiveFilter()
+	if err != nil {
+		return err
+	}
+
 	if !c.IncludeBuiltins {
 		s.FilterBuiltins()
 	}
@@ -68,12 +77,24 @@ func (c LsTypesCmd) Run(ctx Context) error {
 			continue
 		}
 
+		if !directiveFilter.matches(t.Directives) {
+			continue
+		}
+
 		types = append(types, t)
 	}
 	types.Sort()
 
 	if c.Json {
 		return ctx.PrintJson(types)
+	} else if c.Sdl {
+		return printSchemaSdl(ctx, &model.Schema{
+			Types:                types.ToMap(),
+			QueryTypeName:        s.QueryTypeName,
+			MutationTypeName:     s.MutationTypeName,
+			SubscriptionTypeName: s.SubscriptionTypeName,
+			Directives:           s.Directives,
+		})
 	} else {
 		for _, t := range types {
 			directives := ""
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/benweint/gquil/pkg/graph"
+)
+
+// defaultShortestPaths is the number of paths listed when neither --shortest nor --max-length is given.
+const defaultShortestPaths = 10
+
+type PathsCmd struct {
+	InputOptions
+	From               string `name:"from" help:"Type or field to start paths from. Defaults to the schema's query root type."`
+	To                 string `name:"to" required:"" help:"Type or field which paths should end at."`
+	MaxLength          int    `name:"max-length" group:"filtering" help:"Only include paths with at most this many steps."`
+	Shortest           int    `name:"shortest" group:"filtering" help:"Only include the given number of shortest paths. Defaults to 10 if --max-length is not given."`
+	ExcludeArguments   bool   `name:"exclude-arguments" group:"filtering" help:"Don't traverse from fields to the types of their arguments."`
+	InterfacesAsUnions bool   `name:"interfaces-as-unions" help:"Treat interfaces as unions rather than objects, passing through them to each of their implementing types."`
+	OutputOptions
+}
+
+func (c PathsCmd) Help() string {
+	return `Lists the paths through the schema graph from a root type or field to a target type or field, one per line. For example:
+
+  gquil paths --to Repository.issues schema.graphql
+
+might print:
+
+  Query.repository -> Repository.issues
+  Query.viewer -> User.repositories -> Repository.issues
+
+Paths start from the schema's query root type unless --from is given, and never visit the same type twice. Paths are sorted by length, so --shortest can be used to show only the k shortest paths. Use --max-length to limit the number of steps in each path.
+
+Since large schemas can contain an enormous number of paths between two types, only the 10 shortest paths are listed if neither --shortest nor --max-length is given. To list every path up to a given length, use --max-length on its own.
+
+By default, a path may step from a field to the type of one of its arguments, which is shown as 'Type.field(arg:)'. Use --exclude-arguments to only follow the return types of fields.
+
+Unions are treated as pass-through: a path may step from a field returning a union directly to a field on one of its member types. Interfaces are treated like object types by default, but can be treated as pass-through to their implementing types using --interfaces-as-unions.`
+}
+
+func (c PathsCmd) Run(ctx Context) error {
+	s, err := loadSchemaModel(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	from := c.From
+	if from == "" {
+		if s.QueryTypeName == "" {
+			return fmt.Errorf("schema has no query root type, please specify one using --from")
+		}
+		from = s.QueryTypeName
+	}
+
+	names, err := s.ResolveNames([]string{from, c.To})
+	if err != nil {
+		return err
+	}
+
+	var opts []graph.GraphOption
+	if c.InterfacesAsUnions {
+		opts = append(opts, graph.WithInterfacesAsUnions())
+	}
+
+	limit := c.Shortest
+	if limit == 0 && c.MaxLength == 0 {
+		limit = defaultShortestPaths
+	}
+
+	paths := graph.MakeGraph(s, opts...).Paths(names[0], names[1], graph.PathOptions{
+		MaxLength:        c.MaxLength,
+		Limit:            limit,
+		ExcludeArguments: c.ExcludeArguments,
+	})
+
+	if c.Json {
+		if paths == nil {
+			paths = []graph.Path{}
+		}
+		return ctx.PrintJson(paths)
+	}
+
+	for _, p := range paths {
+		ctx.Printf("%s\n", p)
+	}
+
+	return nil
+}
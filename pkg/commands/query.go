@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/benweint/gquil/pkg/execution"
+)
+
+type QueryCmd struct {
+	InputOptions
+	Query         string `name:"query" short:"q" help:"The text of the GraphQL query to execute."`
+	QueryFile     string `name:"query-file" short:"f" help:"Path to a file containing the GraphQL query to execute. Use - to read from stdin."`
+	OperationName string `name:"operation-name" help:"The name of the operation to execute, if the query document contains more than one."`
+	Variables     string `name:"variables" help:"Variables for the query, as a JSON object."`
+}
+
+func (c *QueryCmd) Help() string {
+	return `Executes a GraphQL query against the introspection schema of the given GraphQL SDL document(s), and prints the JSON response. No network requests are made.
+
+Only the introspection meta-fields (__schema, __type, and __typename) are resolved: since there is no server behind the schema, all other fields resolve to null. This allows existing introspection queries and tooling to be used against local schema files. For example, to list the names of all fields on the Query type:
+
+  gquil query -q '{ __type(name: "Query") { fields { name } } }' schema.graphql
+
+Use --variables to pass variables to the query as a JSON object:
+
+  gquil query -q 'query($t: String!) { __type(name: $t) { kind } }' --variables '{"t": "User"}' schema.graphql
+
+If the query produces any errors, the response is still printed (including any partial data), and the command exits with a non-zero status.`
+}
+
+func (c *QueryCmd) Run(ctx Context) error {
+	query, err := c.loadQuery(ctx)
+	if err != nil {
+		return err
+	}
+
+	var variables map[string]any
+	if c.Variables != "" {
+		if err := json.Unmarshal([]byte(c.Variables), &variables); err != nil {
+			return fmt.Errorf("failed to parse --variables as a JSON object: %w", err)
+		}
+	}
+
+	s, err := parseSchemaFromPaths(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	rsp := execution.Execute(s, execution.Request{
+		Query:         query,
+		OperationName: c.OperationName,
+		Variables:     variables,
+	})
+
+	if err := ctx.PrintJson(rsp); err != nil {
+		return err
+	}
+
+	if len(rsp.Errors) > 0 {
+		return fmt.Errorf("query produced %d error(s)", len(rsp.Errors))
+	}
+
+	return nil
+}
+
+func (c *QueryCmd) loadQuery(ctx Context) (string, error) {
+	if c.Query != "" && c.QueryFile != "" {
+		return "", fmt.Errorf("only one of --query or --query-file may be given")
+	}
+
+	if c.Query != "" {
+		return c.Query, nil
+	}
+
+	if c.QueryFile == "" {
+		return "", fmt.Errorf("one of --query or --query-file must be given")
+	}
+
+	var raw []byte
+	var err error
+	if c.QueryFile == "-" {
+		raw, err = io.ReadAll(ctx.Stdin)
+	} else {
+		raw, err = os.ReadFile(c.QueryFile)
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not read query: %w", err)
+	}
+
+	return string(raw), nil
+}
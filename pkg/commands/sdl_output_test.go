@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// TestSdlOutputsAreValid checks that the output of each successful contract, extract, and json --sdl test case
+// can be loaded as a schema in its own right.
+func TestSdlOutputsAreValid(t *testing.T) {
+	for _, tc := range loadTestCases(t) {
+		if len(tc.Args) == 0 || tc.ExpectError {
+			continue
+		}
+		isJsonSdl := tc.Args[0] == "json" && slices.Contains(tc.Args, "--sdl")
+		if !isJsonSdl && !slices.Contains([]string{"contract", "extract", "prune"}, tc.Args[0]) {
+			continue
+		}
+
+		t.Run(tc.Dir, func(t *testing.T) {
+			_, err := gqlparser.LoadSchema(&ast.Source{Name: tc.expectedOutputPath, Input: tc.ExpectedOutput})
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/execution"
+	"github.com/benweint/gquil/pkg/mock"
+)
+
+type ServeCmd struct {
+	InputOptions
+	Listen     string            `name:"listen" default:"localhost:8080" help:"The address to listen on."`
+	Seed       int64             `name:"seed" default:"0" help:"Seed for generating mock data. The same seed always produces the same response for a given query."`
+	ListLength int               `name:"list-length" default:"2" help:"Number of items to return for list fields."`
+	Scalars    map[string]string `name:"scalar" help:"Generator to use for a scalar type, as ScalarName=generator. May be specified multiple times."`
+}
+
+func (c *ServeCmd) Help() string {
+	return fmt.Sprintf(`Starts a local HTTP server which serves a mock GraphQL API for the given GraphQL SDL document(s).
+
+The server answers introspection queries, and returns deterministic mock data for any valid operation. Requests may be made either via POST with a JSON body, or via GET with URL query parameters, though mutations are only accepted via POST. For example:
+
+  gquil serve --listen localhost:4000 schema.graphql
+
+Mock values are derived from --seed and the path of each field in the response. Enum fields return one of the enum's values, and abstract (interface or union) fields return one of their possible types.
+
+Custom scalars return strings by default. Use --scalar to choose a different generator for a scalar type defined in the schema, like this:
+
+  gquil serve --scalar DateTime=datetime --scalar UUID=uuid schema.graphql
+
+Available generators: %s.`, strings.Join(mock.GeneratorNames(), ", "))
+}
+
+func (c *ServeCmd) Run(ctx Context) error {
+	s, err := parseSchemaFromPaths(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	resolver, err := mock.NewResolver(s, mock.Options{
+		Seed:             c.Seed,
+		ListLength:       c.ListLength,
+		ScalarGenerators: c.Scalars,
+	})
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", c.Listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", c.Listen, err)
+	}
+
+	fmt.Fprintf(ctx.Stderr, "Serving mock GraphQL API at http://%s/\n", listener.Addr())
+
+	return http.Serve(listener, execution.NewHandler(s, execution.WithResolver(resolver)))
+}
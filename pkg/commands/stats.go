@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/benweint/gquil/pkg/stats"
+)
+
+type StatsCmd struct {
+	InputOptions
+	Top int `name:"top" default:"10" group:"output" help:"Number of types to list in each of the largest types, fan-in, and fan-out tables."`
+	FilteringOptions
+	OutputOptions
+}
+
+func (c StatsCmd) Help() string {
+	return `Reports statistics about the size and shape of a schema. For example:
+
+  gquil stats examples/github.graphql
+
+The report includes:
+
+  * The number of types of each kind, and the total number of fields, input fields, arguments, enum values, and directive definitions.
+  * The fraction of fields, input fields, arguments, and enum values which are deprecated.
+  * The fraction of types, fields, input fields, arguments, and enum values which have descriptions.
+  * The number of times each directive is applied.
+  * For each root operation type, the number of types reachable from it, and the maximum and average depth of those types. The depth of a type is the minimum number of fields which must be selected to reach it from the root.
+  * The largest types, by number of fields (or enum values, or union members), and the types with the highest fan-in (number of distinct types referring to them) and fan-out (number of distinct types they refer to).
+
+Use --top to control the number of types listed in the last three tables. The --json output includes the per-type statistics for all types, which is useful for tracking how a schema changes over time.`
+}
+
+func (c StatsCmd) Run(ctx Context) error {
+	s, err := loadSchemaModel(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	if !c.IncludeBuiltins {
+		s.FilterBuiltins()
+	}
+
+	report := stats.Compute(s)
+
+	if c.Json {
+		return ctx.PrintJson(report)
+	}
+
+	w := tabwriter.NewWriter(ctx.Stdout, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Types\t%d\n", report.Totals.Types)
+	for _, kc := range report.Kinds {
+		fmt.Fprintf(w, "  %s\t%d\n", kc.Kind, kc.Count)
+	}
+	fmt.Fprintf(w, "Fields\t%d\n", report.Totals.Fields)
+	fmt.Fprintf(w, "Input fields\t%d\n", report.Totals.InputFields)
+	fmt.Fprintf(w, "Arguments\t%d\n", report.Totals.Arguments)
+	fmt.Fprintf(w, "Enum values\t%d\n", report.Totals.EnumValues)
+	fmt.Fprintf(w, "Directive definitions\t%d\n", report.Totals.Directives)
+
+	fmt.Fprintf(w, "\nDeprecated\n")
+	fmt.Fprintf(w, "  fields\t%s\n", report.Deprecated.Fields)
+	fmt.Fprintf(w, "  input fields\t%s\n", report.Deprecated.InputFields)
+	fmt.Fprintf(w, "  arguments\t%s\n", report.Deprecated.Arguments)
+	fmt.Fprintf(w, "  enum values\t%s\n", report.Deprecated.EnumValues)
+
+	fmt.Fprintf(w, "\nWith descriptions\n")
+	fmt.Fprintf(w, "  types\t%s\n", report.Descriptions.Types)
+	fmt.Fprintf(w, "  fields\t%s\n", report.Descriptions.Fields)
+	fmt.Fprintf(w, "  input fields\t%s\n", report.Descriptions.InputFields)
+	fmt.Fprintf(w, "  arguments\t%s\n", report.Descriptions.Arguments)
+	fmt.Fprintf(w, "  enum values\t%s\n", report.Descriptions.EnumValues)
+
+	if len(report.DirectiveUsages) > 0 {
+		fmt.Fprintf(w, "\nDirective usages\n")
+		for _, du := range report.DirectiveUsages {
+			fmt.Fprintf(w, "  @%s\t%d\n", du.Name, du.Count)
+		}
+	}
+
+	if len(report.Roots) > 0 {
+		fmt.Fprintf(w, "\nDepth from root types\n")
+		for _, root := range report.Roots {
+			fmt.Fprintf(w, "  %s (%s)\treachable types: %d, max depth: %d, average depth: %.1f\n", root.TypeName, root.Operation, root.ReachableTypes, root.MaxDepth, root.AverageDepth)
+		}
+	}
+
+	tables := []struct {
+		title string
+		value func(stats.TypeStats) int
+	}{
+		{"Largest types", func(t stats.TypeStats) int { return t.Fields }},
+		{"Highest fan-in", func(t stats.TypeStats) int { return t.FanIn }},
+		{"Highest fan-out", func(t stats.TypeStats) int { return t.FanOut }},
+	}
+	for _, table := range tables {
+		types := report.Largest(c.Top, table.value)
+		if len(types) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s\n", table.title)
+		for _, t := range types {
+			fmt.Fprintf(w, "  %s\t%d\n", t.Name, table.value(t))
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/inputs"
+	"github.com/benweint/gquil/pkg/operations"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type OperationInputOptions struct {
+	SchemaFiles    []string `name:"schema" short:"s" required:"" help:"Path to the GraphQL SDL schema file(s) to read from. Directories and glob patterns are expanded. May be specified multiple times."`
+	OperationFiles []string `arg:"" name:"operations" help:"Path to the GraphQL operation document(s) to read from. Directories will be searched recursively for .graphql and .gql files."`
+}
+
+var operationFileExtensions = []string{".graphql", ".gql"}
+
+// loadOperations reads, parses, and validates the operation documents specified by o against the given schema.
+func (o OperationInputOptions) loadOperations(s *ast.Schema) (*ast.QueryDocument, gqlerror.List, error) {
+	paths, err := inputs.Expand(o.OperationFiles, operationFileExtensions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read operation documents: %w", err)
+	}
+
+	sources, err := readSources(paths, "operation document")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, errs := operations.Parse(sources)
+	if len(errs) > 0 {
+		return nil, errs, nil
+	}
+
+	return doc, operations.Validate(s, doc), nil
+}
+
+// loadValidOperations is like loadOperations, but treats any validation errors as fatal.
+func (o OperationInputOptions) loadValidOperations(s *ast.Schema) (*ast.QueryDocument, error) {
+	doc, errs, err := o.loadOperations(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(errs) > 0 {
+		var formatted []string
+		for _, e := range errs {
+			formatted = append(formatted, formatOperationError(e))
+		}
+		return nil, fmt.Errorf("found %d error(s) in operation documents:\n%s", len(errs), strings.Join(formatted, "\n"))
+	}
+
+	return doc, nil
+}
+
+type ValidateCmd struct {
+	OperationInputOptions
+	OutputOptions
+}
+
+func (c ValidateCmd) Help() string {
+	return `Validates GraphQL operation documents (files containing queries, mutations, subscriptions, and fragments) against a schema, using the validation rules from the GraphQL spec. For example:
+
+  gquil validate --schema schema.graphql queries/*.graphql
+
+Directories given as operation document paths are searched recursively for files with a .graphql or .gql extension, and glob patterns (including **) are expanded. Files and directories listed in .gquilignore files are skipped.
+
+All operation documents are validated together, so fragments defined in one document may be used from another. Each error is reported on its own line, prefixed with the file, line, and column where it occurred. You can use --json to get a JSON list of errors instead, in the format used for errors in GraphQL responses.
+
+Nothing is printed if all documents are valid. The command exits with a non-zero status if any errors are found.`
+}
+
+func (c ValidateCmd) Run(ctx Context) error {
+	s, err := parseSchemaFromPaths(c.SchemaFiles)
+	if err != nil {
+		return err
+	}
+
+	_, errs, err := c.loadOperations(s)
+	if err != nil {
+		return err
+	}
+
+	if c.Json {
+		if len(errs) == 0 {
+			errs = gqlerror.List{}
+		}
+		if err := ctx.PrintJson(errs); err != nil {
+			return err
+		}
+	} else {
+		for _, e := range errs {
+			ctx.Printf("%s\n", formatOperationError(e))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("found %d validation error(s)", len(errs))
+	}
+
+	return nil
+}
+
+func formatOperationError(err *gqlerror.Error) string {
+	location := operations.ErrorFile(err)
+	if location == "" {
+		location = "input"
+	}
+	if len(err.Locations) > 0 {
+		location = fmt.Sprintf("%s:%d:%d", location, err.Locations[0].Line, err.Locations[0].Column)
+	}
+	return fmt.Sprintf("%s: %s", location, err.Message)
+}
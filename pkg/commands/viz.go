@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/benweint/gquil/pkg/explorer"
 	"github.com/benweint/gquil/pkg/graph"
 )
 
@@ -8,7 +9,9 @@ type VizCmd struct {
 	InputOptions
 	FilteringOptions
 	GraphFilteringOptions
-	InterfacesAsUnions bool `name:"interfaces-as-unions" help:"Treat interfaces as unions rather than objects for the purposes of graph construction."`
+	DirectiveFilteringOptions
+	InterfacesAsUnions bool   `name:"interfaces-as-unions" help:"Treat interfaces as unions rather than objects for the purposes of graph construction."`
+	Format             string `name:"format" group:"output" enum:"dot,mermaid,html" default:"dot" help:"Output format. One of dot, mermaid, html."`
 }
 
 func (c *VizCmd) Help() string {
@@ -20,6 +23,20 @@ For GraphQL schemas with a large number of types and fields, the resulting diagr
 
   gquil viz --from Reviews --depth 2 schema.graphql | dot -Tpdf >out.pdf
 
+Conversely, you can use the --to and --depth-reverse flags to only show the types and fields from which a given type or field can be reached. Combining --from and --to shows only the paths between them.
+
+  gquil viz --from Query --to User.email schema.graphql | dot -Tpdf >out.pdf
+
+You can also emit a Mermaid class diagram instead of GraphViz DOT using --format mermaid, which is useful for embedding in Markdown documents:
+
+  gquil viz --format mermaid --from Query.fruit schema.graphql
+
+Finally, --format html produces a single self-contained HTML file for browsing the schema in a web browser, with search, a page per type, links between types and the fields which refer to them, and a view of each type's neighbors in the graph. It needs no network access or other tools to view:
+
+  gquil viz --format html schema.graphql >schema.html
+
+You can use --with-directive and --without-directive to restrict the graph to the types and fields where a given directive is (or is not) applied, as described in the help for the json subcommand.
+
 GraphQL unions are represented as nodes in the graph with outbound edges to each member type. Interfaces are represented in the same way as object types by default, with one outbound edge per field, pointing to the type of that field. To instead render interfaces with one outbound edge per implementing type, you can use the --interfaces-as-unions flag.`
 }
 
@@ -29,6 +46,11 @@ func (c *VizCmd) Run(ctx Context) error {
 		return err
 	}
 
+	directiveFilter, err := c.directiveFilter()
+	if err != nil {
+		return err
+	}
+
 	var opts []graph.GraphOption
 	if c.InterfacesAsUnions {
 		opts = append(opts, graph.WithInterfacesAsUnions())
@@ -38,17 +60,34 @@ func (c *VizCmd) Run(ctx Context) error {
 		opts = append(opts, graph.WithBuiltins(true))
 	}
 
-	g := graph.MakeGraph(s, opts...)
+	// As in the json subcommand, --from and --to are resolved against the full schema, before the directive
+	// filter is applied.
+	g, err := c.filterGraph(s, graph.MakeGraph(s, opts...))
+	if err != nil {
+		return err
+	}
+	if !directiveFilter.empty() {
+		s.Types = g.GetDefinitions()
+		directiveFilter.filterSchema(s)
+		g = graph.MakeGraph(s, opts...)
+	}
 
-	if len(c.From) > 0 {
-		roots, err := s.ResolveNames(c.From)
+	switch c.Format {
+	case "html":
+		s.Types = g.GetDefinitions()
+		if !c.IncludeBuiltins {
+			s.FilterBuiltins()
+		}
+		page, err := explorer.Render(s)
 		if err != nil {
 			return err
 		}
-		g = g.ReachableFrom(roots, c.Depth)
+		ctx.Print(page)
+	case "mermaid":
+		ctx.Print(g.ToMermaid())
+	default:
+		ctx.Print(g.ToDot())
 	}
 
-	ctx.Print(g.ToDot())
-
 	return nil
 }
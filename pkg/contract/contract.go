@@ -0,0 +1,541 @@
+// Package contract produces filtered variants of a schema (contracts), based on the directives applied to its
+// types and fields.
+package contract
+
+import (
+	"fmt"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Rules determines which elements of a schema are part of a contract. Each rule is a directive pattern, as
+// accepted by model.Directive.Matches.
+//
+// Types, fields, arguments, input fields, and enum values matching any Exclude rule are removed. If any
+// Include rules are given, fields of object and interface types are kept only if they, or the type they are
+// defined on, match one of them, though Exclude rules take precedence.
+type Rules struct {
+	Include []*model.Directive
+	Exclude []*model.Directive
+}
+
+// Apply returns a copy of s with the elements excluded by the given rules removed. The input schema is not
+// modified.
+//
+// Removals cascade: fields, arguments, and input fields referring to removed types are removed, as are union
+// members and interface implementations referring to removed types. Types with no remaining fields, enum
+// values, or possible types are removed, as are types which were reachable from the root operation types in
+// the original schema but are no longer reachable once other elements have been removed. An object or
+// interface which no longer has all of the fields of an interface it implements, with the same arguments,
+// stops implementing it.
+//
+// An error is returned if a non-null field, required argument, or required input field would refer to a
+// removed type, if a default value or applied directive argument would refer to a removed enum value or input
+// field, or if the query root type would be removed. Arguments of applied directives are removed along with the
+// corresponding argument definitions.
+func Apply(s *ast.Schema, rules Rules) (*ast.Schema, error) {
+	c := &contract{
+		source: s,
+		rules:  rules,
+		types:  map[string]*ast.Definition{},
+	}
+
+	originallyReachable := reachable(s.Types, s.Directives, rootNames(s))
+
+	if err := c.applyRules(); err != nil {
+		return nil, err
+	}
+
+	for {
+		if err := c.cascade(); err != nil {
+			return nil, err
+		}
+
+		nowReachable := reachable(c.types, s.Directives, rootNames(s))
+		removed := false
+		for _, name := range astutil.SortedKeys(c.types) {
+			if originallyReachable[name] && !nowReachable[name] {
+				delete(c.types, name)
+				removed = true
+			}
+		}
+		if !removed {
+			break
+		}
+	}
+
+	if err := c.checkValues(); err != nil {
+		return nil, err
+	}
+
+	return c.build()
+}
+
+type contract struct {
+	source *ast.Schema
+	rules  Rules
+	types  map[string]*ast.Definition
+}
+
+func (c *contract) matchesAny(dl ast.DirectiveList, patterns []*model.Directive) (bool, error) {
+	if len(patterns) == 0 {
+		return false, nil
+	}
+	directives, err := model.MakeDirectiveList(dl)
+	if err != nil {
+		return false, err
+	}
+	for _, pattern := range patterns {
+		if directives.Contains(pattern) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *contract) excluded(dl ast.DirectiveList) (bool, error) {
+	return c.matchesAny(dl, c.rules.Exclude)
+}
+
+// applyRules populates c.types with copies of the source types, with excluded elements removed.
+func (c *contract) applyRules() error {
+	for _, name := range astutil.SortedKeys(c.source.Types) {
+		def := c.source.Types[name]
+		if astutil.IsBuiltinType(name) {
+			c.types[name] = def
+			continue
+		}
+
+		isExcluded, err := c.excluded(def.Directives)
+		if err != nil {
+			return err
+		}
+		if isExcluded {
+			continue
+		}
+
+		isIncluded := len(c.rules.Include) == 0
+		if !isIncluded {
+			if isIncluded, err = c.matchesAny(def.Directives, c.rules.Include); err != nil {
+				return err
+			}
+		}
+
+		result := *def
+		result.Fields = nil
+		for _, f := range def.Fields {
+			keep, err := c.keepField(def, f, isIncluded)
+			if err != nil {
+				return err
+			}
+			if !keep {
+				continue
+			}
+
+			field := *f
+			field.Arguments = nil
+			for _, arg := range f.Arguments {
+				isExcluded, err := c.excluded(arg.Directives)
+				if err != nil {
+					return err
+				}
+				if !isExcluded {
+					argCopy := *arg
+					field.Arguments = append(field.Arguments, &argCopy)
+				}
+			}
+			result.Fields = append(result.Fields, &field)
+		}
+
+		result.EnumValues = nil
+		for _, ev := range def.EnumValues {
+			isExcluded, err := c.excluded(ev.Directives)
+			if err != nil {
+				return err
+			}
+			if !isExcluded {
+				evCopy := *ev
+				result.EnumValues = append(result.EnumValues, &evCopy)
+			}
+		}
+
+		result.Interfaces = append([]string{}, def.Interfaces...)
+		result.Types = append([]string{}, def.Types...)
+		c.types[name] = &result
+	}
+
+	return nil
+}
+
+func (c *contract) keepField(def *ast.Definition, f *ast.FieldDefinition, typeIncluded bool) (bool, error) {
+	isExcluded, err := c.excluded(f.Directives)
+	if err != nil || isExcluded {
+		return false, err
+	}
+	if typeIncluded || def.Kind == ast.InputObject {
+		return true, nil
+	}
+	return c.matchesAny(f.Directives, c.rules.Include)
+}
+
+// removed returns true if the named type existed in the source schema, but not in the contract.
+func (c *contract) removed(name string) bool {
+	_, inSource := c.source.Types[name]
+	_, inContract := c.types[name]
+	return inSource && !inContract
+}
+
+// cascade repeatedly removes elements which refer to removed types, and types which are left empty, until
+// there is nothing more to remove.
+func (c *contract) cascade() error {
+	for changed := true; changed; {
+		changed = false
+		for _, name := range astutil.SortedKeys(c.types) {
+			def := c.types[name]
+			if astutil.IsBuiltinType(name) {
+				continue
+			}
+
+			before := len(def.Fields) + len(def.Interfaces) + len(def.Types)
+			if err := c.cascadeDefinition(def); err != nil {
+				return err
+			}
+			if len(def.Fields)+len(def.Interfaces)+len(def.Types) != before {
+				changed = true
+			}
+
+			if isEmpty(def) {
+				delete(c.types, name)
+				changed = true
+			}
+		}
+	}
+	return nil
+}
+
+func (c *contract) cascadeDefinition(def *ast.Definition) error {
+	var fields ast.FieldList
+	for _, f := range def.Fields {
+		coordinate := def.Name + "." + f.Name
+		if c.removed(f.Type.Name()) {
+			if isRequired(f.Type, f.DefaultValue, def.Kind == ast.Object || def.Kind == ast.Interface) {
+				return fmt.Errorf("'%s' is non-null, but its type '%s' is not part of the contract", coordinate, f.Type.Name())
+			}
+			continue
+		}
+
+		var args ast.ArgumentDefinitionList
+		for _, arg := range f.Arguments {
+			if c.removed(arg.Type.Name()) {
+				if isRequired(arg.Type, arg.DefaultValue, false) {
+					return fmt.Errorf("argument '%s' is required, but its type '%s' is not part of the contract", astutil.ArgumentCoordinate(coordinate, arg.Name), arg.Type.Name())
+				}
+				continue
+			}
+			args = append(args, arg)
+		}
+		f.Arguments = args
+
+		fields = append(fields, f)
+	}
+	def.Fields = fields
+
+	var interfaces []string
+	for _, iface := range def.Interfaces {
+		if ifaceDef := c.types[iface]; ifaceDef != nil && canImplement(def, ifaceDef) {
+			interfaces = append(interfaces, iface)
+		}
+	}
+	def.Interfaces = interfaces
+
+	var members []string
+	for _, member := range def.Types {
+		if !c.removed(member) {
+			members = append(members, member)
+		}
+	}
+	def.Types = members
+
+	return nil
+}
+
+// checkValues ensures that default values and applied directive arguments don't refer to enum values or
+// input fields which are not part of the contract. Arguments to applied directives whose definitions no longer
+// include them are removed.
+func (c *contract) checkValues() error {
+	for _, name := range astutil.SortedKeys(c.types) {
+		def := c.types[name]
+		if astutil.IsBuiltinType(name) {
+			continue
+		}
+
+		var err error
+		if def.Directives, err = c.checkDirectives(def.Directives, name); err != nil {
+			return err
+		}
+
+		for _, f := range def.Fields {
+			coordinate := name + "." + f.Name
+			if err := c.checkValue(f.DefaultValue, f.Type, "the default value of '"+coordinate+"'"); err != nil {
+				return err
+			}
+			if f.Directives, err = c.checkDirectives(f.Directives, coordinate); err != nil {
+				return err
+			}
+
+			for _, arg := range f.Arguments {
+				argCoordinate := astutil.ArgumentCoordinate(coordinate, arg.Name)
+				if err := c.checkValue(arg.DefaultValue, arg.Type, "the default value of '"+argCoordinate+"'"); err != nil {
+					return err
+				}
+				if arg.Directives, err = c.checkDirectives(arg.Directives, argCoordinate); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, ev := range def.EnumValues {
+			if ev.Directives, err = c.checkDirectives(ev.Directives, name+"."+ev.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range astutil.SortedKeys(c.source.Directives) {
+		for _, arg := range c.source.Directives[name].Arguments {
+			where := fmt.Sprintf("the default value of '%s'", astutil.ArgumentCoordinate("@"+name, arg.Name))
+			if err := c.checkValue(arg.DefaultValue, arg.Type, where); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDirectives checks the arguments of the given applied directives, returning a copy of the list with any
+// arguments which are no longer defined removed.
+func (c *contract) checkDirectives(dl ast.DirectiveList, coordinate string) (ast.DirectiveList, error) {
+	var result ast.DirectiveList
+	for _, d := range dl {
+		dd := c.source.Directives[d.Name]
+		if dd == nil {
+			result = append(result, d)
+			continue
+		}
+
+		directive := *d
+		directive.Arguments = nil
+		for _, arg := range d.Arguments {
+			argDef := dd.Arguments.ForName(arg.Name)
+			if argDef == nil {
+				directive.Arguments = append(directive.Arguments, arg)
+				continue
+			}
+			if c.removed(argDef.Type.Name()) {
+				continue
+			}
+			where := fmt.Sprintf("the '%s' argument of '@%s' on '%s'", arg.Name, d.Name, coordinate)
+			if err := c.checkValue(arg.Value, argDef.Type, where); err != nil {
+				return nil, err
+			}
+			directive.Arguments = append(directive.Arguments, arg)
+		}
+		result = append(result, &directive)
+	}
+	return result, nil
+}
+
+// checkValue returns an error if the given value, of the given type, refers to an enum value or input field
+// which is not part of the contract. The given description of where the value appears is used in the error.
+func (c *contract) checkValue(v *ast.Value, t *ast.Type, where string) error {
+	if v == nil || v.Kind == ast.NullValue {
+		return nil
+	}
+
+	if t.Elem != nil {
+		if v.Kind != ast.ListValue {
+			return c.checkValue(v, t.Elem, where)
+		}
+		for _, child := range v.Children {
+			if err := c.checkValue(child.Value, t.Elem, where); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	sourceDef := c.source.Types[t.NamedType]
+	if sourceDef == nil {
+		return nil
+	}
+	def := c.types[t.NamedType]
+
+	switch {
+	case sourceDef.Kind == ast.Enum && v.Kind == ast.EnumValue:
+		if def == nil || def.EnumValues.ForName(v.Raw) == nil {
+			return fmt.Errorf("%s refers to the enum value '%s.%s', which is not part of the contract", where, sourceDef.Name, v.Raw)
+		}
+	case sourceDef.Kind == ast.InputObject && v.Kind == ast.ObjectValue:
+		for _, child := range v.Children {
+			sourceField := sourceDef.Fields.ForName(child.Name)
+			if sourceField == nil {
+				continue
+			}
+			if def == nil || def.Fields.ForName(child.Name) == nil {
+				return fmt.Errorf("%s refers to the input field '%s.%s', which is not part of the contract", where, sourceDef.Name, child.Name)
+			}
+			if err := c.checkValue(child.Value, sourceField.Type, where); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *contract) build() (*ast.Schema, error) {
+	out := &ast.Schema{
+		Description: c.source.Description,
+		Types:       c.types,
+		Directives:  map[string]*ast.DirectiveDefinition{},
+	}
+
+	for _, name := range astutil.SortedKeys(c.source.Directives) {
+		def := *c.source.Directives[name]
+		def.Arguments = nil
+		for _, arg := range c.source.Directives[name].Arguments {
+			if c.removed(arg.Type.Name()) {
+				if isRequired(arg.Type, arg.DefaultValue, false) {
+					return nil, fmt.Errorf("argument '%s' is required, but its type '%s' is not part of the contract", astutil.ArgumentCoordinate("@"+name, arg.Name), arg.Type.Name())
+				}
+				continue
+			}
+			def.Arguments = append(def.Arguments, arg)
+		}
+		out.Directives[name] = &def
+	}
+
+	astutil.LinkPossibleTypes(out)
+
+	if c.source.Query != nil {
+		out.Query = out.Types[c.source.Query.Name]
+		if out.Query == nil {
+			return nil, fmt.Errorf("the query root type '%s' is not part of the contract", c.source.Query.Name)
+		}
+	}
+	if c.source.Mutation != nil {
+		out.Mutation = out.Types[c.source.Mutation.Name]
+	}
+	if c.source.Subscription != nil {
+		out.Subscription = out.Types[c.source.Subscription.Name]
+	}
+
+	return out, nil
+}
+
+// reachable returns the names of the types reachable from the given roots, by following fields, arguments,
+// input fields, union members, and interface implementations. Types used by directive arguments are also
+// considered reachable.
+func reachable(types map[string]*ast.Definition, directives map[string]*ast.DirectiveDefinition, roots []string) map[string]bool {
+	implementations := map[string][]string{}
+	for _, name := range astutil.SortedKeys(types) {
+		for _, iface := range types[name].Interfaces {
+			implementations[iface] = append(implementations[iface], name)
+		}
+	}
+
+	seen := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		def := types[name]
+		if def == nil || seen[name] {
+			return
+		}
+		seen[name] = true
+
+		for _, f := range def.Fields {
+			visit(f.Type.Name())
+			for _, arg := range f.Arguments {
+				visit(arg.Type.Name())
+			}
+		}
+		for _, member := range def.Types {
+			visit(member)
+		}
+		for _, implementation := range implementations[name] {
+			visit(implementation)
+		}
+	}
+
+	for _, root := range roots {
+		visit(root)
+	}
+	for _, name := range astutil.SortedKeys(directives) {
+		for _, arg := range directives[name].Arguments {
+			visit(arg.Type.Name())
+		}
+	}
+
+	return seen
+}
+
+func rootNames(s *ast.Schema) []string {
+	var result []string
+	for _, root := range []*ast.Definition{s.Query, s.Mutation, s.Subscription} {
+		if root != nil {
+			result = append(result, root.Name)
+		}
+	}
+	return result
+}
+
+// isRequired returns true if an element of the given type can't be omitted. Output fields can't be omitted
+// if they are non-null, while arguments and input fields can't be omitted if they are non-null and have no
+// default value.
+func isRequired(t *ast.Type, defaultValue *ast.Value, isOutput bool) bool {
+	if !t.NonNull {
+		return false
+	}
+	return isOutput || defaultValue == nil
+}
+
+// canImplement returns true if def has all of the fields of iface, with the same arguments. Any additional
+// arguments must be optional.
+func canImplement(def, iface *ast.Definition) bool {
+	for _, ifaceField := range iface.Fields {
+		f := def.Fields.ForName(ifaceField.Name)
+		if f == nil {
+			return false
+		}
+		for _, arg := range ifaceField.Arguments {
+			if f.Arguments.ForName(arg.Name) == nil {
+				return false
+			}
+		}
+		for _, arg := range f.Arguments {
+			if ifaceField.Arguments.ForName(arg.Name) == nil && isRequired(arg.Type, arg.DefaultValue, false) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isEmpty(def *ast.Definition) bool {
+	switch def.Kind {
+	case ast.Object, ast.Interface, ast.InputObject:
+		for _, f := range def.Fields {
+			if !astutil.IsBuiltinField(f.Name) {
+				return false
+			}
+		}
+		return true
+	case ast.Enum:
+		return len(def.EnumValues) == 0
+	case ast.Union:
+		return len(def.Types) == 0
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+package contract
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+const testSchema = `directive @tag(name: String!) repeatable on OBJECT | FIELD_DEFINITION | ARGUMENT_DEFINITION | ENUM_VALUE | INPUT_FIELD_DEFINITION | INTERFACE | UNION
+directive @internal on OBJECT | FIELD_DEFINITION | ARGUMENT_DEFINITION | ENUM_VALUE | INPUT_FIELD_DEFINITION
+
+type Query {
+	user(id: ID!, debug: Boolean @internal): User @tag(name: "public")
+	search(filter: Filter): [SearchResult] @tag(name: "public")
+	audit: AuditLog @internal
+	node(id: ID!): Node @tag(name: "public")
+}
+
+interface Node {
+	id: ID!
+}
+
+type User implements Node @tag(name: "public") {
+	id: ID!
+	name: String
+	role: Role
+	secret: String @internal
+}
+
+type Team implements Node {
+	id: ID!
+	members: [User] @tag(name: "public")
+}
+
+union SearchResult = User | Team | AuditLog
+
+type AuditLog @internal {
+	entries: [String]
+}
+
+enum Role {
+	ADMIN @internal
+	MEMBER
+}
+
+input Filter {
+	text: String
+	includeDeleted: Boolean @internal
+}`
+
+func TestApply(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected string
+	}{
+		{
+			name:    "exclude",
+			exclude: []string{"internal"},
+			expected: `input Filter {
+	text: String
+}
+interface Node {
+	id: ID!
+}
+type Query {
+	user(id: ID!): User @tag(name: "public")
+	search(filter: Filter): [SearchResult] @tag(name: "public")
+	node(id: ID!): Node @tag(name: "public")
+}
+enum Role {
+	MEMBER
+}
+union SearchResult = User | Team
+type Team implements Node {
+	id: ID!
+	members: [User] @tag(name: "public")
+}
+type User implements Node @tag(name: "public") {
+	id: ID!
+	name: String
+	role: Role
+}
+`,
+		},
+		{
+			name:    "include and exclude",
+			include: []string{`tag(name: "public")`},
+			exclude: []string{"internal"},
+			expected: `input Filter {
+	text: String
+}
+type Query {
+	user(id: ID!): User @tag(name: "public")
+	search(filter: Filter): [SearchResult] @tag(name: "public")
+}
+enum Role {
+	MEMBER
+}
+union SearchResult = User | Team
+type Team {
+	members: [User] @tag(name: "public")
+}
+type User @tag(name: "public") {
+	id: ID!
+	name: String
+	role: Role
+}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+			result, err := Apply(s, Rules{
+				Include: parsePatterns(t, tc.include),
+				Exclude: parsePatterns(t, tc.exclude),
+			})
+			assert.NoError(t, err)
+
+			astutil.FilterBuiltins(result)
+			var buf bytes.Buffer
+			formatter.NewFormatter(&buf).FormatSchema(result)
+			actual := buf.String()
+
+			// Directive definitions are always retained, so strip them to keep the expectations short.
+			actual = strings.Join(slices.DeleteFunc(strings.SplitAfter(actual, "\n"), func(line string) bool {
+				return strings.HasPrefix(line, "directive ")
+			}), "")
+			assert.Equal(t, tc.expected, actual)
+
+			_, err = gqlparser.LoadSchema(&ast.Source{Name: "contract", Input: buf.String()})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		exclude  []string
+		expected string
+	}{
+		{
+			name: "non-null field",
+			schema: `directive @internal on OBJECT
+type Query { secret: Secret! }
+type Secret @internal { value: String }`,
+			exclude:  []string{"internal"},
+			expected: "'Query.secret' is non-null, but its type 'Secret' is not part of the contract",
+		},
+		{
+			name: "required argument",
+			schema: `directive @internal on INPUT_OBJECT
+type Query { search(filter: Filter!): String }
+input Filter @internal { text: String }`,
+			exclude:  []string{"internal"},
+			expected: "argument 'Query.search(filter:)' is required, but its type 'Filter' is not part of the contract",
+		},
+		{
+			name: "enum default value",
+			schema: `directive @internal on ENUM_VALUE
+type Query { paint(color: Color = BLUE): String }
+enum Color { RED BLUE @internal }`,
+			exclude:  []string{"internal"},
+			expected: "the default value of 'Query.paint(color:)' refers to the enum value 'Color.BLUE', which is not part of the contract",
+		},
+		{
+			name: "nested enum default value",
+			schema: `directive @internal on ENUM_VALUE
+type Query { paint(options: Options = {colors: [RED, BLUE]}): String }
+input Options { colors: [Color!] }
+enum Color { RED BLUE @internal }`,
+			exclude:  []string{"internal"},
+			expected: "the default value of 'Query.paint(options:)' refers to the enum value 'Color.BLUE', which is not part of the contract",
+		},
+		{
+			name: "input field default value",
+			schema: `directive @internal on INPUT_FIELD_DEFINITION
+type Query { search(filter: Filter = {text: "x", debug: true}): String }
+input Filter { text: String debug: Boolean @internal }`,
+			exclude:  []string{"internal"},
+			expected: "the default value of 'Query.search(filter:)' refers to the input field 'Filter.debug', which is not part of the contract",
+		},
+		{
+			name: "applied directive argument",
+			schema: `directive @internal on ENUM_VALUE
+directive @auth(role: Role) on FIELD_DEFINITION
+type Query { secret: String @auth(role: ADMIN) }
+enum Role { ADMIN @internal MEMBER }`,
+			exclude:  []string{"internal"},
+			expected: "the 'role' argument of '@auth' on 'Query.secret' refers to the enum value 'Role.ADMIN', which is not part of the contract",
+		},
+		{
+			name: "empty query type",
+			schema: `directive @internal on FIELD_DEFINITION
+type Query { secret: String @internal }`,
+			exclude:  []string{"internal"},
+			expected: "the query root type 'Query' is not part of the contract",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: tc.schema})
+			_, err := Apply(s, Rules{Exclude: parsePatterns(t, tc.exclude)})
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestApplyInterfaceArguments(t *testing.T) {
+	s := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: `directive @internal on ARGUMENT_DEFINITION | INPUT_OBJECT
+type Query { a: A, b: B }
+interface Node { id(x: Int): ID! }
+type A implements Node { id(x: Int @internal): ID! }
+type B implements Node { id(x: Int, y: Int, z: Secret): ID! }
+input Secret @internal { value: String }`})
+
+	result, err := Apply(s, Rules{Exclude: parsePatterns(t, []string{"internal"})})
+	assert.NoError(t, err)
+
+	// A no longer has the argument declared by Node, while B still does, and only lost an optional argument.
+	assert.Empty(t, result.Types["A"].Interfaces)
+	assert.Equal(t, []string{"Node"}, result.Types["B"].Interfaces)
+
+	astutil.FilterBuiltins(result)
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatSchema(result)
+	_, err = gqlparser.LoadSchema(&ast.Source{Name: "contract", Input: buf.String()})
+	assert.NoError(t, err)
+}
+
+func TestApplyStripsRemovedDirectiveArguments(t *testing.T) {
+	s := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: `directive @internal on INPUT_OBJECT
+directive @meta(label: String, extra: Extra) on FIELD_DEFINITION
+type Query { a: String @meta(label: "x", extra: {value: "y"}) }
+input Extra @internal { value: String }`})
+
+	result, err := Apply(s, Rules{Exclude: parsePatterns(t, []string{"internal"})})
+	assert.NoError(t, err)
+
+	applied := result.Types["Query"].Fields.ForName("a").Directives.ForName("meta")
+	assert.Len(t, applied.Arguments, 1)
+	assert.Equal(t, "label", applied.Arguments[0].Name)
+	assert.Len(t, s.Types["Query"].Fields.ForName("a").Directives.ForName("meta").Arguments, 2)
+}
+
+func TestApplyDoesNotModifyInput(t *testing.T) {
+	s := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+	_, err := Apply(s, Rules{Exclude: parsePatterns(t, []string{"internal"})})
+	assert.NoError(t, err)
+
+	assert.NotNil(t, s.Types["AuditLog"])
+	assert.Len(t, s.Types["User"].Fields, 4)
+	assert.Len(t, s.Types["Query"].Fields.ForName("user").Arguments, 2)
+	assert.Equal(t, []string{"User", "Team", "AuditLog"}, s.Types["SearchResult"].Types)
+}
+
+func parsePatterns(t *testing.T, raw []string) []*model.Directive {
+	var result []*model.Directive
+	for _, r := range raw {
+		d, err := model.ParseDirective(r)
+		assert.NoError(t, err)
+		result = append(result, d)
+	}
+	return result
+}
@@ -0,0 +1,270 @@
+package coverage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Usage records which operations make use of a single schema element.
+//
+// Coordinates use the same <type>.<field> notation used elsewhere in gquil, with arguments
+// represented as <type>.<field>(<arg>:), and enum values as <enum>.<value>.
+type Usage struct {
+	Coordinate string   `json:"coordinate"`
+	TypeName   string   `json:"typeName,omitempty"`
+	Count      int      `json:"count"`
+	Operations []string `json:"operations,omitempty"`
+}
+
+// Summary describes what fraction of a set of schema elements is used.
+type Summary struct {
+	Used    int     `json:"used"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d/%d (%.1f%%)", s.Used, s.Total, s.Percent)
+}
+
+// UsageList is a list of usages for a set of schema elements of the same kind.
+type UsageList []*Usage
+
+// Summarize returns a Summary of the given usage list.
+func (ul UsageList) Summarize() Summary {
+	s := Summary{
+		Total:   len(ul),
+		Percent: 100,
+	}
+	for _, u := range ul {
+		if u.Count > 0 {
+			s.Used++
+		}
+	}
+	if s.Total > 0 {
+		s.Percent = 100 * float64(s.Used) / float64(s.Total)
+	}
+	return s
+}
+
+// Report describes which fields (including input fields), arguments, and enum values in a schema
+// are used by a set of operations.
+type Report struct {
+	Fields     UsageList `json:"fields"`
+	Arguments  UsageList `json:"arguments"`
+	EnumValues UsageList `json:"enumValues"`
+}
+
+// Compute determines which elements of s are used by the operations in doc. The given document must
+// have already been validated against the schema that s was constructed from, since validation annotates
+// the selections in the document with their definitions.
+//
+// The following rules are used to decide whether an element is used:
+//
+//   - A field is used if it is selected, either directly or via an interface it is declared on.
+//   - An argument is used if it is provided in a selection.
+//   - An input field or enum value is used if it appears in a literal value. If an argument value is
+//     provided via a variable, all input fields and enum values reachable from its type are considered used.
+//   - All values of an enum are considered used if a field of that enum type is selected.
+//
+// Built-in types and fields are not included in the report.
+func Compute(s *model.Schema, doc *ast.QueryDocument) *Report {
+	w := &walker{
+		schema: s,
+		used:   map[string]map[string]bool{},
+	}
+
+	for _, op := range doc.Operations {
+		w.operation = operationName(op)
+		w.seenFragments = map[string]bool{}
+		w.seenInputTypes = map[string]bool{}
+		w.selectionSet(op.SelectionSet)
+	}
+
+	report := &Report{
+		Fields:     UsageList{},
+		Arguments:  UsageList{},
+		EnumValues: UsageList{},
+	}
+	for _, t := range s.Types.ToSortedList() {
+		if astutil.IsBuiltinType(t.Name) {
+			continue
+		}
+		for _, f := range sortedFields(t.Fields) {
+			if astutil.IsBuiltinField(f.Name) {
+				continue
+			}
+			fieldCoordinate := t.Name + "." + f.Name
+			report.Fields = append(report.Fields, w.usage(fieldCoordinate, f.Type.String()))
+			for _, arg := range f.Arguments {
+				report.Arguments = append(report.Arguments, w.usage(astutil.ArgumentCoordinate(fieldCoordinate, arg.Name), arg.Type.String()))
+			}
+		}
+		for _, ev := range t.EnumValues {
+			report.EnumValues = append(report.EnumValues, w.usage(t.Name+"."+ev.Name, ""))
+		}
+	}
+
+	sort.Slice(report.Arguments, func(i, j int) bool {
+		return strings.Compare(report.Arguments[i].Coordinate, report.Arguments[j].Coordinate) < 0
+	})
+
+	return report
+}
+
+type walker struct {
+	schema         *model.Schema
+	used           map[string]map[string]bool
+	operation      string
+	seenFragments  map[string]bool
+	seenInputTypes map[string]bool
+}
+
+func (w *walker) mark(coordinate string) {
+	ops, ok := w.used[coordinate]
+	if !ok {
+		ops = map[string]bool{}
+		w.used[coordinate] = ops
+	}
+	ops[w.operation] = true
+}
+
+func (w *walker) usage(coordinate, typeName string) *Usage {
+	var ops []string
+	for op := range w.used[coordinate] {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	return &Usage{
+		Coordinate: coordinate,
+		TypeName:   typeName,
+		Count:      len(ops),
+		Operations: ops,
+	}
+}
+
+func (w *walker) selectionSet(ss ast.SelectionSet) {
+	for _, sel := range ss {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			w.field(sel)
+		case *ast.InlineFragment:
+			w.selectionSet(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			if sel.Definition == nil || w.seenFragments[sel.Name] {
+				continue
+			}
+			w.seenFragments[sel.Name] = true
+			w.selectionSet(sel.Definition.SelectionSet)
+		}
+	}
+}
+
+func (w *walker) field(f *ast.Field) {
+	if f.Definition == nil || f.ObjectDefinition == nil || astutil.IsBuiltinField(f.Name) {
+		return
+	}
+
+	parentName := f.ObjectDefinition.Name
+	fieldCoordinate := parentName + "." + f.Name
+	w.mark(fieldCoordinate)
+
+	// A field selected via an interface may be resolved by any of its implementations.
+	if parent := w.schema.Types[parentName]; parent != nil && parent.Kind == ast.Interface {
+		for _, possibleType := range parent.PossibleTypes {
+			w.mark(possibleType + "." + f.Name)
+		}
+	}
+
+	for _, arg := range f.Arguments {
+		w.mark(astutil.ArgumentCoordinate(fieldCoordinate, arg.Name))
+		w.value(arg.Value)
+	}
+
+	if returnType := w.schema.Types[f.Definition.Type.Name()]; returnType != nil && returnType.Kind == ast.Enum {
+		w.markAllValues(returnType)
+	}
+
+	w.selectionSet(f.SelectionSet)
+}
+
+func (w *walker) value(v *ast.Value) {
+	if v == nil {
+		return
+	}
+
+	switch v.Kind {
+	case ast.Variable:
+		if v.ExpectedType != nil {
+			w.markInputType(v.ExpectedType.Name())
+		}
+	case ast.EnumValue:
+		if v.Definition != nil {
+			w.mark(v.Definition.Name + "." + v.Raw)
+		}
+	case ast.ObjectValue:
+		for _, child := range v.Children {
+			if v.Definition != nil {
+				w.mark(v.Definition.Name + "." + child.Name)
+			}
+			w.value(child.Value)
+		}
+	case ast.ListValue:
+		for _, child := range v.Children {
+			w.value(child.Value)
+		}
+	}
+}
+
+// markInputType marks all input fields and enum values reachable from the named input type as used.
+func (w *walker) markInputType(name string) {
+	if w.seenInputTypes[name] {
+		return
+	}
+	w.seenInputTypes[name] = true
+
+	def := w.schema.Types[name]
+	if def == nil {
+		return
+	}
+
+	switch def.Kind {
+	case ast.Enum:
+		w.markAllValues(def)
+	case ast.InputObject:
+		for _, f := range def.Fields {
+			w.mark(def.Name + "." + f.Name)
+			w.markInputType(f.Type.Unwrap().Name)
+		}
+	}
+}
+
+func (w *walker) markAllValues(def *model.Definition) {
+	for _, ev := range def.EnumValues {
+		w.mark(def.Name + "." + ev.Name)
+	}
+}
+
+// operationName returns a name identifying the given operation. Anonymous operations are identified
+// by their source location instead.
+func operationName(op *ast.OperationDefinition) string {
+	if op.Name != "" {
+		return op.Name
+	}
+	if op.Position != nil && op.Position.Src != nil {
+		return fmt.Sprintf("<anonymous>@%s:%d", op.Position.Src.Name, op.Position.Line)
+	}
+	return "<anonymous>"
+}
+
+func sortedFields(fields model.FieldDefinitionList) model.FieldDefinitionList {
+	result := make(model.FieldDefinitionList, len(fields))
+	copy(result, fields)
+	result.Sort()
+	return result
+}
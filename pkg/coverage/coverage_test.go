@@ -0,0 +1,133 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/benweint/gquil/pkg/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testSchema = `type Query {
+	search(filter: Filter, order: Order): [Named]
+	person(id: ID!): Person
+}
+
+input Filter {
+	name: String
+	kind: Kind
+	nested: NestedFilter
+}
+
+input NestedFilter {
+	limit: Int
+}
+
+enum Kind { PERSON, PLACE }
+enum Order { ASC, DESC }
+
+interface Named {
+	name: String
+}
+
+type Person implements Named {
+	name: String
+	kind: Kind
+	age: Int
+}
+
+type Place implements Named {
+	name: String
+}`
+
+func TestCompute(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		operations         string
+		expectedFields     []string
+		expectedArguments  []string
+		expectedEnumValues []string
+	}{
+		{
+			name:               "interface fields",
+			operations:         `query A { search { name } }`,
+			expectedFields:     []string{"Named.name", "Person.name", "Place.name", "Query.search"},
+			expectedArguments:  nil,
+			expectedEnumValues: nil,
+		},
+		{
+			name:               "literal arguments",
+			operations:         `query A { search(filter: {kind: PLACE}, order: DESC) { ... on Person { age } } }`,
+			expectedFields:     []string{"Filter.kind", "Person.age", "Query.search"},
+			expectedArguments:  []string{"Query.search(filter:)", "Query.search(order:)"},
+			expectedEnumValues: []string{"Kind.PLACE", "Order.DESC"},
+		},
+		{
+			name:               "variable arguments",
+			operations:         `query A($f: Filter) { search(filter: $f) { name } }`,
+			expectedFields:     []string{"Filter.kind", "Filter.name", "Filter.nested", "Named.name", "NestedFilter.limit", "Person.name", "Place.name", "Query.search"},
+			expectedArguments:  []string{"Query.search(filter:)"},
+			expectedEnumValues: []string{"Kind.PERSON", "Kind.PLACE"},
+		},
+		{
+			name: "enum-typed fields and fragments",
+			operations: `query A { person(id: "1") { ...P } }
+			query B { person(id: "2") { ...P } }
+			fragment P on Person { kind }`,
+			expectedFields:     []string{"Person.kind", "Query.person"},
+			expectedArguments:  []string{"Query.person(id:)"},
+			expectedEnumValues: []string{"Kind.PERSON", "Kind.PLACE"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rawSchema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+			s, err := model.MakeSchema(rawSchema)
+			assert.NoError(t, err)
+
+			doc, errs := operations.Parse([]*ast.Source{{Name: "ops", Input: tc.operations}})
+			assert.Empty(t, errs)
+			assert.Empty(t, operations.Validate(rawSchema, doc))
+
+			report := Compute(s, doc)
+			assert.Equal(t, tc.expectedFields, usedCoordinates(report.Fields))
+			assert.Equal(t, tc.expectedArguments, usedCoordinates(report.Arguments))
+			assert.Equal(t, tc.expectedEnumValues, usedCoordinates(report.EnumValues))
+		})
+	}
+}
+
+func TestOperationCounts(t *testing.T) {
+	rawSchema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+	s, err := model.MakeSchema(rawSchema)
+	assert.NoError(t, err)
+
+	doc, errs := operations.Parse([]*ast.Source{
+		{Name: "a.graphql", Input: `query A { person(id: "1") { name } }`},
+		{Name: "b.graphql", Input: `{ person(id: "2") { name age } }`},
+	})
+	assert.Empty(t, errs)
+	assert.Empty(t, operations.Validate(rawSchema, doc))
+
+	report := Compute(s, doc)
+	for _, u := range report.Fields {
+		switch u.Coordinate {
+		case "Person.name":
+			assert.Equal(t, []string{"<anonymous>@b.graphql:1", "A"}, u.Operations)
+			assert.Equal(t, 2, u.Count)
+		case "Person.age":
+			assert.Equal(t, 1, u.Count)
+		}
+	}
+}
+
+func usedCoordinates(ul UsageList) []string {
+	var result []string
+	for _, u := range ul {
+		if u.Count > 0 {
+			result = append(result, u.Coordinate)
+		}
+	}
+	return result
+}
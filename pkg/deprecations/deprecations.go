@@ -0,0 +1,158 @@
+// Package deprecations finds the deprecated elements of a schema, and reports on whether they are still
+// reachable and in use.
+package deprecations
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/coverage"
+	"github.com/benweint/gquil/pkg/graph"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Kind identifies the kind of schema element which is deprecated.
+type Kind string
+
+const (
+	KindField      Kind = "field"
+	KindArgument   Kind = "argument"
+	KindInputField Kind = "inputField"
+	KindEnumValue  Kind = "enumValue"
+)
+
+// Deprecation describes a single deprecated field, argument, input field, or enum value.
+//
+// Coordinates use the same notation as the coverage package. Replacement is a best-effort guess at the
+// element which should be used instead, parsed from the reason text. Reachable indicates whether the element
+// can be reached from one of the schema's root operation types. Operations is only populated if operation
+// documents were given, and lists the names of the operations which use the element.
+type Deprecation struct {
+	Coordinate  string   `json:"coordinate"`
+	Kind        Kind     `json:"kind"`
+	Reason      string   `json:"reason"`
+	Replacement string   `json:"replacement,omitempty"`
+	Reachable   bool     `json:"reachable"`
+	Operations  []string `json:"operations,omitempty"`
+}
+
+// Find returns all of the deprecated elements in the given schema, sorted by kind and then coordinate.
+// Built-in types should not be filtered out of s beforehand, since they are needed to determine reachability,
+// but are omitted from the result.
+//
+// If doc is non-nil, the Operations of each deprecation are populated from it. As with coverage.Compute,
+// the document must have already been validated against the schema that s was constructed from.
+func Find(s *model.Schema, doc *ast.QueryDocument) []*Deprecation {
+	reachable := reachableDefinitions(s)
+
+	var fields, arguments, inputFields, enumValues []*Deprecation
+	for _, t := range s.Types.ToSortedList() {
+		if astutil.IsBuiltinType(t.Name) {
+			continue
+		}
+		reachableType := reachable[t.Name]
+
+		for _, f := range t.Fields {
+			fieldCoordinate := t.Name + "." + f.Name
+			reachableField := reachableType != nil && reachableType.Fields.Named(f.Name) != nil
+
+			if reason, ok := f.Directives.DeprecationReason(); ok {
+				if t.Kind == ast.InputObject {
+					inputFields = append(inputFields, newDeprecation(fieldCoordinate, KindInputField, reason, reachableField))
+				} else {
+					fields = append(fields, newDeprecation(fieldCoordinate, KindField, reason, reachableField))
+				}
+			}
+
+			for _, arg := range f.Arguments {
+				if reason, ok := arg.Directives.DeprecationReason(); ok {
+					coordinate := astutil.ArgumentCoordinate(fieldCoordinate, arg.Name)
+					arguments = append(arguments, newDeprecation(coordinate, KindArgument, reason, reachableField))
+				}
+			}
+		}
+
+		for _, ev := range t.EnumValues {
+			if reason, ok := ev.Directives.DeprecationReason(); ok {
+				enumValues = append(enumValues, newDeprecation(t.Name+"."+ev.Name, KindEnumValue, reason, reachableType != nil))
+			}
+		}
+	}
+
+	result := []*Deprecation{}
+	for _, group := range [][]*Deprecation{fields, arguments, inputFields, enumValues} {
+		sortByCoordinate(group)
+		result = append(result, group...)
+	}
+
+	if doc != nil {
+		addOperations(result, coverage.Compute(s, doc))
+	}
+
+	return result
+}
+
+func newDeprecation(coordinate string, kind Kind, reason string, reachable bool) *Deprecation {
+	return &Deprecation{
+		Coordinate:  coordinate,
+		Kind:        kind,
+		Reason:      reason,
+		Replacement: ParseReplacement(reason),
+		Reachable:   reachable,
+	}
+}
+
+// reachableDefinitions returns the types and fields reachable from the root operation types of s.
+func reachableDefinitions(s *model.Schema) model.DefinitionMap {
+	var roots []*model.NameReference
+	for _, name := range []string{s.QueryTypeName, s.MutationTypeName, s.SubscriptionTypeName} {
+		if _, ok := s.Types[name]; ok {
+			ref := model.TypeNameReference(name)
+			roots = append(roots, &ref)
+		}
+	}
+	if len(roots) == 0 {
+		return model.DefinitionMap{}
+	}
+	return graph.MakeGraph(s).ReachableFrom(roots, 0).GetDefinitions()
+}
+
+func addOperations(deprecations []*Deprecation, report *coverage.Report) {
+	operations := map[string][]string{}
+	for _, usages := range []coverage.UsageList{report.Fields, report.Arguments, report.EnumValues} {
+		for _, u := range usages {
+			operations[u.Coordinate] = u.Operations
+		}
+	}
+
+	for _, d := range deprecations {
+		d.Operations = operations[d.Coordinate]
+	}
+}
+
+// replacementPattern matches phrases like "Use `foo` instead", "Use Foo.bar instead", or "replaced by foo.",
+// capturing the replacement in either the first (quoted) or second (unquoted) group. Unquoted replacements
+// must be followed by "instead" or the end of a sentence, to avoid matching ordinary prose.
+var replacementPattern = regexp.MustCompile("(?i)\\b(?:use|replaced\\s+by|superseded\\s+by|in\\s+favou?r\\s+of)\\s+(?:the\\s+)?(?:`([^`]+)`|([A-Za-z_][\\w.]*\\w(?:\\(\\w+:\\))?)(?:\\s+instead\\b|[.,;](?:\\s|$)|$))")
+
+// ParseReplacement returns the replacement suggested by the given deprecation reason, or the empty string
+// if none can be found.
+func ParseReplacement(reason string) string {
+	m := replacementPattern.FindStringSubmatch(reason)
+	if m == nil {
+		return ""
+	}
+	if m[1] != "" {
+		return strings.TrimSpace(m[1])
+	}
+	return m[2]
+}
+
+func sortByCoordinate(deprecations []*Deprecation) {
+	sort.Slice(deprecations, func(i, j int) bool {
+		return deprecations[i].Coordinate < deprecations[j].Coordinate
+	})
+}
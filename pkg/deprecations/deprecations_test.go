@@ -0,0 +1,79 @@
+package deprecations
+
+import (
+	"testing"
+
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/benweint/gquil/pkg/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testSchema = `type Query {
+	person(id: ID!, name: String @deprecated(reason: "Use ` + "`id`" + ` instead.")): Person
+	people(filter: Filter): [Person] @deprecated
+}
+
+input Filter {
+	name: String @deprecated(reason: "Replaced by Filter.names.")
+	names: [String]
+}
+
+enum Kind {
+	HUMAN
+	ROBOT @deprecated(reason: "Robots are no longer people")
+}
+
+type Person {
+	name: String
+	kind: Kind
+	age: Int @deprecated(reason: "Use birthDate instead")
+	birthDate: String
+}
+
+type Orphan {
+	old: String @deprecated(reason: "Unreachable")
+}`
+
+func TestFind(t *testing.T) {
+	rawSchema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+	s, err := model.MakeSchema(rawSchema)
+	assert.NoError(t, err)
+
+	doc, errs := operations.Parse([]*ast.Source{{Name: "ops", Input: `
+		query A { person(id: "1") { age } }
+		query B { people(filter: {name: "x"}) { name } }
+	`}})
+	assert.Empty(t, errs)
+	assert.Empty(t, operations.Validate(rawSchema, doc))
+
+	assert.Equal(t, []*Deprecation{
+		{Coordinate: "Orphan.old", Kind: KindField, Reason: "Unreachable", Reachable: false},
+		{Coordinate: "Person.age", Kind: KindField, Reason: "Use birthDate instead", Replacement: "birthDate", Reachable: true, Operations: []string{"A"}},
+		{Coordinate: "Query.people", Kind: KindField, Reason: "No longer supported", Reachable: true, Operations: []string{"B"}},
+		{Coordinate: "Query.person(name:)", Kind: KindArgument, Reason: "Use `id` instead.", Replacement: "id", Reachable: true},
+		{Coordinate: "Filter.name", Kind: KindInputField, Reason: "Replaced by Filter.names.", Replacement: "Filter.names", Reachable: true, Operations: []string{"B"}},
+		{Coordinate: "Kind.ROBOT", Kind: KindEnumValue, Reason: "Robots are no longer people", Reachable: true},
+	}, Find(s, doc))
+}
+
+func TestParseReplacement(t *testing.T) {
+	for _, tc := range []struct {
+		reason   string
+		expected string
+	}{
+		{"Use `newField` instead.", "newField"},
+		{"Assignees can now be mannequins. Use the `assignee` field instead. Removal on 2020-01-01 UTC.", "assignee"},
+		{"`timeline` will be removed Use Issue.timelineItems instead. Removal on 2020-10-01 UTC.", "Issue.timelineItems"},
+		{"Superseded by Query.search(filter:).", "Query.search(filter:)"},
+		{"Deprecated in favor of newThing", "newThing"},
+		{"The feature is deprecated in favor of Organization Discussions.", ""},
+		{"No longer supported", ""},
+		{"Because nobody uses it.", ""},
+	} {
+		t.Run(tc.reason, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ParseReplacement(tc.reason))
+		})
+	}
+}
@@ -0,0 +1,524 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Criticality describes the impact that a given Change may have on existing clients of a schema.
+type Criticality string
+
+const (
+	// Breaking changes will cause some previously-valid operations to become invalid, or to
+	// return results that existing clients may not be able to handle.
+	Breaking = Criticality("BREAKING")
+
+	// Dangerous changes will not invalidate existing operations, but may change runtime behavior
+	// in ways that existing clients are not prepared for (e.g. new enum values or union members).
+	Dangerous = Criticality("DANGEROUS")
+
+	// Safe changes are backwards-compatible with all existing clients.
+	Safe = Criticality("SAFE")
+)
+
+func (c Criticality) rank() int {
+	switch c {
+	case Breaking:
+		return 0
+	case Dangerous:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ChangeKind identifies the type of a Change.
+type ChangeKind string
+
+const (
+	TypeAdded                    = ChangeKind("TYPE_ADDED")
+	TypeRemoved                  = ChangeKind("TYPE_REMOVED")
+	TypeKindChanged              = ChangeKind("TYPE_KIND_CHANGED")
+	TypeDescriptionChanged       = ChangeKind("TYPE_DESCRIPTION_CHANGED")
+	FieldAdded                   = ChangeKind("FIELD_ADDED")
+	FieldRemoved                 = ChangeKind("FIELD_REMOVED")
+	FieldTypeChanged             = ChangeKind("FIELD_TYPE_CHANGED")
+	FieldDescriptionChanged      = ChangeKind("FIELD_DESCRIPTION_CHANGED")
+	FieldDeprecationAdded        = ChangeKind("FIELD_DEPRECATION_ADDED")
+	FieldDeprecationRemoved      = ChangeKind("FIELD_DEPRECATION_REMOVED")
+	InputFieldAdded              = ChangeKind("INPUT_FIELD_ADDED")
+	InputFieldRemoved            = ChangeKind("INPUT_FIELD_REMOVED")
+	InputFieldTypeChanged        = ChangeKind("INPUT_FIELD_TYPE_CHANGED")
+	InputFieldDefaultChanged     = ChangeKind("INPUT_FIELD_DEFAULT_VALUE_CHANGED")
+	ArgumentAdded                = ChangeKind("ARGUMENT_ADDED")
+	ArgumentRemoved              = ChangeKind("ARGUMENT_REMOVED")
+	ArgumentTypeChanged          = ChangeKind("ARGUMENT_TYPE_CHANGED")
+	ArgumentDefaultChanged       = ChangeKind("ARGUMENT_DEFAULT_VALUE_CHANGED")
+	EnumValueAdded               = ChangeKind("ENUM_VALUE_ADDED")
+	EnumValueRemoved             = ChangeKind("ENUM_VALUE_REMOVED")
+	EnumValueDeprecationAdded    = ChangeKind("ENUM_VALUE_DEPRECATION_ADDED")
+	EnumValueDeprecationRemoved  = ChangeKind("ENUM_VALUE_DEPRECATION_REMOVED")
+	UnionMemberAdded             = ChangeKind("UNION_MEMBER_ADDED")
+	UnionMemberRemoved           = ChangeKind("UNION_MEMBER_REMOVED")
+	InterfaceImplementationAdded = ChangeKind("INTERFACE_IMPLEMENTATION_ADDED")
+	InterfaceImplementationGone  = ChangeKind("INTERFACE_IMPLEMENTATION_REMOVED")
+	DirectiveAdded               = ChangeKind("DIRECTIVE_ADDED")
+	DirectiveRemoved             = ChangeKind("DIRECTIVE_REMOVED")
+	DirectiveLocationAdded       = ChangeKind("DIRECTIVE_LOCATION_ADDED")
+	DirectiveLocationRemoved     = ChangeKind("DIRECTIVE_LOCATION_REMOVED")
+	DirectiveRepeatableChanged   = ChangeKind("DIRECTIVE_REPEATABLE_CHANGED")
+	RootTypeChanged              = ChangeKind("ROOT_TYPE_CHANGED")
+)
+
+// Change represents a single difference between two schemas.
+//
+// Path identifies the schema element that was changed, using the same <type>.<field> notation
+// accepted by --from, extended with (<arg>:) for arguments and a leading '@' for directives.
+type Change struct {
+	Criticality Criticality `json:"criticality"`
+	Kind        ChangeKind  `json:"kind"`
+	Path        string      `json:"path"`
+	Message     string      `json:"message"`
+}
+
+func (c *Change) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Criticality, c.Path, c.Message)
+}
+
+// ChangeList represents the full set of changes between two schemas.
+type ChangeList []*Change
+
+// Sort orders the changes by criticality (most severe first), and then by path.
+func (cl ChangeList) Sort() {
+	sort.SliceStable(cl, func(i, j int) bool {
+		a, b := cl[i], cl[j]
+		if a.Criticality != b.Criticality {
+			return a.Criticality.rank() < b.Criticality.rank()
+		}
+		return strings.Compare(a.Path, b.Path) < 0
+	})
+}
+
+// Count returns the number of changes with the given criticality.
+func (cl ChangeList) Count(c Criticality) int {
+	n := 0
+	for _, change := range cl {
+		if change.Criticality == c {
+			n++
+		}
+	}
+	return n
+}
+
+// HasBreaking returns true if at least one of the changes in the list is breaking.
+func (cl ChangeList) HasBreaking() bool {
+	return cl.Count(Breaking) > 0
+}
+
+type differ struct {
+	changes ChangeList
+}
+
+func (d *differ) add(criticality Criticality, kind ChangeKind, path string, msg string, args ...any) {
+	d.changes = append(d.changes, &Change{
+		Criticality: criticality,
+		Kind:        kind,
+		Path:        path,
+		Message:     fmt.Sprintf(msg, args...),
+	})
+}
+
+// Compare returns the list of changes required to get from oldSchema to newSchema, sorted by
+// criticality and path. The result is empty, rather than nil, if there are no changes.
+func Compare(oldSchema, newSchema *model.Schema) ChangeList {
+	d := &differ{changes: ChangeList{}}
+	d.compareRootTypes(oldSchema, newSchema)
+	d.compareTypes(oldSchema.Types, newSchema.Types)
+	d.compareDirectiveDefinitions(oldSchema.Directives, newSchema.Directives)
+	d.changes.Sort()
+	return d.changes
+}
+
+func (d *differ) compareRootTypes(oldSchema, newSchema *model.Schema) {
+	for _, root := range []struct {
+		operation string
+		old, new  string
+	}{
+		{"query", oldSchema.QueryTypeName, newSchema.QueryTypeName},
+		{"mutation", oldSchema.MutationTypeName, newSchema.MutationTypeName},
+		{"subscription", oldSchema.SubscriptionTypeName, newSchema.SubscriptionTypeName},
+	} {
+		if root.old == root.new {
+			continue
+		}
+		switch {
+		case root.old == "":
+			d.add(Safe, RootTypeChanged, root.new, "%s root type '%s' was added", root.operation, root.new)
+		case root.new == "":
+			d.add(Breaking, RootTypeChanged, root.old, "%s root type '%s' was removed", root.operation, root.old)
+		default:
+			d.add(Breaking, RootTypeChanged, root.new, "%s root type changed from '%s' to '%s'", root.operation, root.old, root.new)
+		}
+	}
+}
+
+func (d *differ) compareTypes(oldTypes, newTypes model.DefinitionMap) {
+	for _, name := range unionOfKeys(oldTypes, newTypes) {
+		oldType, newType := oldTypes[name], newTypes[name]
+
+		if newType == nil {
+			d.add(Breaking, TypeRemoved, name, "%s '%s' was removed", kindDescription(oldType.Kind), name)
+			continue
+		}
+
+		if oldType == nil {
+			d.add(Safe, TypeAdded, name, "%s '%s' was added", kindDescription(newType.Kind), name)
+			continue
+		}
+
+		if oldType.Kind != newType.Kind {
+			d.add(Breaking, TypeKindChanged, name, "'%s' changed from %s to %s", name, kindDescription(oldType.Kind), kindDescription(newType.Kind))
+			continue
+		}
+
+		if oldType.Description != newType.Description {
+			d.add(Safe, TypeDescriptionChanged, name, "description changed")
+		}
+
+		switch newType.Kind {
+		case ast.Object, ast.Interface:
+			d.compareOutputFields(oldType, newType)
+			d.compareInterfaces(oldType, newType)
+		case ast.InputObject:
+			d.compareInputFields(oldType, newType)
+		case ast.Enum:
+			d.compareEnumValues(oldType, newType)
+		case ast.Union:
+			d.compareUnionMembers(oldType, newType)
+		}
+	}
+}
+
+func (d *differ) compareOutputFields(oldType, newType *model.Definition) {
+	kindName := kindDescription(newType.Kind)
+	for _, name := range unionOfFieldNames(oldType.Fields, newType.Fields) {
+		path := newType.Name + "." + name
+		oldField, newField := oldType.Fields.Named(name), newType.Fields.Named(name)
+
+		if newField == nil {
+			d.add(Breaking, FieldRemoved, path, "field '%s' was removed from %s '%s'", name, kindName, newType.Name)
+			continue
+		}
+
+		if oldField == nil {
+			d.add(Safe, FieldAdded, path, "field '%s' was added to %s '%s'", name, kindName, newType.Name)
+			continue
+		}
+
+		if oldField.Type.String() != newField.Type.String() {
+			criticality := Breaking
+			if isSafeOutputTypeChange(oldField.Type, newField.Type) {
+				criticality = Safe
+			}
+			d.add(criticality, FieldTypeChanged, path, "type changed from '%s' to '%s'", oldField.Type, newField.Type)
+		}
+
+		if oldField.Description != newField.Description {
+			d.add(Safe, FieldDescriptionChanged, path, "description changed")
+		}
+
+		d.compareDeprecation(oldField.Directives, newField.Directives, path, FieldDeprecationAdded, FieldDeprecationRemoved)
+		d.compareArguments(oldField.Arguments, newField.Arguments, path)
+	}
+}
+
+func (d *differ) compareInputFields(oldType, newType *model.Definition) {
+	for _, name := range unionOfFieldNames(oldType.Fields, newType.Fields) {
+		path := newType.Name + "." + name
+		oldField, newField := oldType.Fields.Named(name), newType.Fields.Named(name)
+
+		if newField == nil {
+			d.add(Breaking, InputFieldRemoved, path, "input field '%s' was removed from input object type '%s'", name, newType.Name)
+			continue
+		}
+
+		if oldField == nil {
+			if isRequired(newField.Type, newField.DefaultValue) {
+				d.add(Breaking, InputFieldAdded, path, "required input field '%s' was added to input object type '%s'", name, newType.Name)
+			} else {
+				d.add(Dangerous, InputFieldAdded, path, "optional input field '%s' was added to input object type '%s'", name, newType.Name)
+			}
+			continue
+		}
+
+		if oldField.Type.String() != newField.Type.String() {
+			criticality := Breaking
+			if isSafeInputTypeChange(oldField.Type, newField.Type) {
+				criticality = Safe
+			}
+			d.add(criticality, InputFieldTypeChanged, path, "type changed from '%s' to '%s'", oldField.Type, newField.Type)
+		}
+
+		if !valuesEqual(oldField.DefaultValue, newField.DefaultValue) {
+			d.add(Dangerous, InputFieldDefaultChanged, path, "default value changed from %s to %s", formatValue(oldField.DefaultValue), formatValue(newField.DefaultValue))
+		}
+
+		if oldField.Description != newField.Description {
+			d.add(Safe, FieldDescriptionChanged, path, "description changed")
+		}
+
+		d.compareDeprecation(oldField.Directives, newField.Directives, path, FieldDeprecationAdded, FieldDeprecationRemoved)
+	}
+}
+
+func (d *differ) compareArguments(oldArgs, newArgs model.ArgumentDefinitionList, parentPath string) {
+	var names []string
+	for _, arg := range append(slices.Clone(oldArgs), newArgs...) {
+		names = append(names, arg.Name)
+	}
+
+	for _, name := range unionOfStrings(names, nil) {
+		path := astutil.ArgumentCoordinate(parentPath, name)
+		oldArg, newArg := oldArgs.Named(name), newArgs.Named(name)
+
+		if newArg == nil {
+			d.add(Breaking, ArgumentRemoved, path, "argument '%s' was removed", name)
+			continue
+		}
+
+		if oldArg == nil {
+			if isRequired(newArg.Type, newArg.DefaultValue) {
+				d.add(Breaking, ArgumentAdded, path, "required argument '%s' was added", name)
+			} else {
+				d.add(Dangerous, ArgumentAdded, path, "optional argument '%s' was added", name)
+			}
+			continue
+		}
+
+		if oldArg.Type.String() != newArg.Type.String() {
+			criticality := Breaking
+			if isSafeInputTypeChange(oldArg.Type, newArg.Type) {
+				criticality = Safe
+			}
+			d.add(criticality, ArgumentTypeChanged, path, "type changed from '%s' to '%s'", oldArg.Type, newArg.Type)
+		}
+
+		if !valuesEqual(oldArg.DefaultValue, newArg.DefaultValue) {
+			d.add(Dangerous, ArgumentDefaultChanged, path, "default value changed from %s to %s", formatValue(oldArg.DefaultValue), formatValue(newArg.DefaultValue))
+		}
+	}
+}
+
+func (d *differ) compareInterfaces(oldType, newType *model.Definition) {
+	for _, name := range unionOfStrings(oldType.Interfaces, newType.Interfaces) {
+		inOld, inNew := slices.Contains(oldType.Interfaces, name), slices.Contains(newType.Interfaces, name)
+		if inOld && !inNew {
+			d.add(Breaking, InterfaceImplementationGone, newType.Name, "no longer implements interface '%s'", name)
+		} else if !inOld && inNew {
+			d.add(Dangerous, InterfaceImplementationAdded, newType.Name, "now implements interface '%s'", name)
+		}
+	}
+}
+
+func (d *differ) compareUnionMembers(oldType, newType *model.Definition) {
+	for _, name := range unionOfStrings(oldType.PossibleTypes, newType.PossibleTypes) {
+		inOld, inNew := slices.Contains(oldType.PossibleTypes, name), slices.Contains(newType.PossibleTypes, name)
+		if inOld && !inNew {
+			d.add(Breaking, UnionMemberRemoved, newType.Name, "member '%s' was removed from union", name)
+		} else if !inOld && inNew {
+			d.add(Dangerous, UnionMemberAdded, newType.Name, "member '%s' was added to union", name)
+		}
+	}
+}
+
+func (d *differ) compareEnumValues(oldType, newType *model.Definition) {
+	var names []string
+	for _, ev := range append(slices.Clone(oldType.EnumValues), newType.EnumValues...) {
+		names = append(names, ev.Name)
+	}
+
+	for _, name := range unionOfStrings(names, nil) {
+		path := newType.Name + "." + name
+		oldValue, newValue := oldType.EnumValues.Named(name), newType.EnumValues.Named(name)
+		if newValue == nil {
+			d.add(Breaking, EnumValueRemoved, path, "enum value '%s' was removed from enum '%s'", name, newType.Name)
+			continue
+		}
+
+		if oldValue == nil {
+			d.add(Dangerous, EnumValueAdded, path, "enum value '%s' was added to enum '%s'", name, newType.Name)
+			continue
+		}
+
+		d.compareDeprecation(oldValue.Directives, newValue.Directives, path, EnumValueDeprecationAdded, EnumValueDeprecationRemoved)
+	}
+}
+
+func (d *differ) compareDeprecation(oldDirectives, newDirectives model.DirectiveList, path string, addedKind, removedKind ChangeKind) {
+	wasDeprecated := oldDirectives.Named("deprecated") != nil
+	isDeprecated := newDirectives.Named("deprecated") != nil
+	if !wasDeprecated && isDeprecated {
+		d.add(Safe, addedKind, path, "was deprecated")
+	} else if wasDeprecated && !isDeprecated {
+		d.add(Safe, removedKind, path, "is no longer deprecated")
+	}
+}
+
+func (d *differ) compareDirectiveDefinitions(oldDirectives, newDirectives model.DirectiveDefinitionList) {
+	oldByName := map[string]*model.DirectiveDefinition{}
+	for _, dd := range oldDirectives {
+		oldByName[dd.Name] = dd
+	}
+	newByName := map[string]*model.DirectiveDefinition{}
+	for _, dd := range newDirectives {
+		newByName[dd.Name] = dd
+	}
+
+	for _, name := range unionOfKeys(oldByName, newByName) {
+		path := "@" + name
+		oldDir, newDir := oldByName[name], newByName[name]
+
+		if newDir == nil {
+			d.add(Breaking, DirectiveRemoved, path, "directive '%s' was removed", name)
+			continue
+		}
+
+		if oldDir == nil {
+			d.add(Safe, DirectiveAdded, path, "directive '%s' was added", name)
+			continue
+		}
+
+		for _, loc := range oldDir.Locations {
+			if !slices.Contains(newDir.Locations, loc) {
+				d.add(Breaking, DirectiveLocationRemoved, path, "location '%s' was removed", loc)
+			}
+		}
+		for _, loc := range newDir.Locations {
+			if !slices.Contains(oldDir.Locations, loc) {
+				d.add(Safe, DirectiveLocationAdded, path, "location '%s' was added", loc)
+			}
+		}
+
+		if oldDir.IsRepeatable && !newDir.IsRepeatable {
+			d.add(Breaking, DirectiveRepeatableChanged, path, "directive is no longer repeatable")
+		} else if !oldDir.IsRepeatable && newDir.IsRepeatable {
+			d.add(Safe, DirectiveRepeatableChanged, path, "directive is now repeatable")
+		}
+
+		d.compareArguments(oldDir.Arguments, newDir.Arguments, path)
+	}
+}
+
+// isSafeOutputTypeChange returns true if a field previously of type oldType may be changed to newType without
+// breaking existing clients. For output types, this is only the case if the new type is the same as the old
+// type, with additional non-null wrappers.
+func isSafeOutputTypeChange(oldType, newType *model.Type) bool {
+	switch oldType.Kind {
+	case model.NonNullKind:
+		return newType.Kind == model.NonNullKind && isSafeOutputTypeChange(oldType.OfType, newType.OfType)
+	case model.ListKind:
+		if newType.Kind == model.ListKind {
+			return isSafeOutputTypeChange(oldType.OfType, newType.OfType)
+		}
+		return newType.Kind == model.NonNullKind && isSafeOutputTypeChange(oldType, newType.OfType)
+	default:
+		if newType.Kind == model.NonNullKind {
+			return isSafeOutputTypeChange(oldType, newType.OfType)
+		}
+		return newType.Kind != model.ListKind && oldType.Name == newType.Name
+	}
+}
+
+// isSafeInputTypeChange returns true if an argument or input field previously of type oldType may be changed
+// to newType without breaking existing clients. For input types, this is only the case if the new type is the
+// same as the old type, with some non-null wrappers removed.
+func isSafeInputTypeChange(oldType, newType *model.Type) bool {
+	switch oldType.Kind {
+	case model.NonNullKind:
+		if newType.Kind == model.NonNullKind {
+			return isSafeInputTypeChange(oldType.OfType, newType.OfType)
+		}
+		return isSafeInputTypeChange(oldType.OfType, newType)
+	case model.ListKind:
+		return newType.Kind == model.ListKind && isSafeInputTypeChange(oldType.OfType, newType.OfType)
+	default:
+		return newType.Kind != model.ListKind && newType.Kind != model.NonNullKind && oldType.Name == newType.Name
+	}
+}
+
+func isRequired(t *model.Type, defaultValue model.Value) bool {
+	return t.Kind == model.NonNullKind && defaultValue == nil
+}
+
+func valuesEqual(a, b model.Value) bool {
+	return formatValue(a) == formatValue(b)
+}
+
+func formatValue(v model.Value) string {
+	if v == nil {
+		return "(none)"
+	}
+	formatted, err := model.FormatValue(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return formatted
+}
+
+func kindDescription(kind ast.DefinitionKind) string {
+	switch kind {
+	case ast.Object:
+		return "object type"
+	case ast.Interface:
+		return "interface"
+	case ast.Union:
+		return "union"
+	case ast.Enum:
+		return "enum"
+	case ast.InputObject:
+		return "input object type"
+	case ast.Scalar:
+		return "scalar"
+	default:
+		return strings.ToLower(string(kind))
+	}
+}
+
+func unionOfKeys[T any](a, b map[string]T) []string {
+	var result []string
+	for k := range a {
+		result = append(result, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			result = append(result, k)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func unionOfFieldNames(a, b model.FieldDefinitionList) []string {
+	var names []string
+	for _, f := range append(slices.Clone(a), b...) {
+		names = append(names, f.Name)
+	}
+	return unionOfStrings(names, nil)
+}
+
+func unionOfStrings(a, b []string) []string {
+	var result []string
+	for _, s := range append(slices.Clone(a), b...) {
+		if !slices.Contains(result, s) {
+			result = append(result, s)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,137 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestCompare(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		old      string
+		new      string
+		expected []string
+	}{
+		{
+			name:     "identical",
+			old:      `type Query { a: String }`,
+			new:      `type Query { a: String }`,
+			expected: nil,
+		},
+		{
+			name: "fields added and removed",
+			old:  `type Query { a: String, b: Int }`,
+			new:  `type Query { a: String, c: Int }`,
+			expected: []string{
+				"BREAKING Query.b: field 'b' was removed from object type 'Query'",
+				"SAFE Query.c: field 'c' was added to object type 'Query'",
+			},
+		},
+		{
+			name: "output field nullability",
+			old:  `type Query { a: String, b: [String!]! }`,
+			new:  `type Query { a: String!, b: [String] }`,
+			expected: []string{
+				"BREAKING Query.b: type changed from '[String!]!' to '[String]'",
+				"SAFE Query.a: type changed from 'String' to 'String!'",
+			},
+		},
+		{
+			name: "argument nullability",
+			old:  `type Query { a(x: Int, y: Int!): String }`,
+			new:  `type Query { a(x: Int!, y: Int): String }`,
+			expected: []string{
+				"BREAKING Query.a(x:): type changed from 'Int' to 'Int!'",
+				"SAFE Query.a(y:): type changed from 'Int!' to 'Int'",
+			},
+		},
+		{
+			name: "arguments added",
+			old:  `type Query { a: String }`,
+			new:  `type Query { a(x: Int!, y: Int, z: Int! = 1): String }`,
+			expected: []string{
+				"BREAKING Query.a(x:): required argument 'x' was added",
+				"DANGEROUS Query.a(y:): optional argument 'y' was added",
+				"DANGEROUS Query.a(z:): optional argument 'z' was added",
+			},
+		},
+		{
+			name: "enum values",
+			old:  `type Query { c: Color } enum Color { RED, GREEN }`,
+			new:  `type Query { c: Color } enum Color { RED, BLUE }`,
+			expected: []string{
+				"BREAKING Color.GREEN: enum value 'GREEN' was removed from enum 'Color'",
+				"DANGEROUS Color.BLUE: enum value 'BLUE' was added to enum 'Color'",
+			},
+		},
+		{
+			name: "union members and interfaces",
+			old: `type Query { u: U }
+				union U = A | B
+				interface Named { name: String }
+				type A implements Named { name: String }
+				type B { name: String }`,
+			new: `type Query { u: U }
+				union U = A
+				interface Named { name: String }
+				type A { name: String }
+				type B implements Named { name: String }`,
+			expected: []string{
+				"BREAKING A: no longer implements interface 'Named'",
+				"BREAKING U: member 'B' was removed from union",
+				"DANGEROUS B: now implements interface 'Named'",
+			},
+		},
+		{
+			name: "type kind changed",
+			old:  `type Query { a: A } type A { name: String }`,
+			new:  `type Query { a: A } interface A { name: String }`,
+			expected: []string{
+				"BREAKING A: 'A' changed from object type to interface",
+			},
+		},
+		{
+			name: "directive definitions",
+			old:  `directive @a(x: Int) repeatable on FIELD_DEFINITION | OBJECT directive @b on OBJECT type Query { a: String }`,
+			new:  `directive @a(x: Int, y: Int!) on FIELD_DEFINITION directive @c on OBJECT type Query { a: String }`,
+			expected: []string{
+				"BREAKING @a: location 'OBJECT' was removed",
+				"BREAKING @a: directive is no longer repeatable",
+				"BREAKING @a(y:): required argument 'y' was added",
+				"BREAKING @b: directive 'b' was removed",
+				"SAFE @c: directive 'c' was added",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oldSchema := loadSchema(t, tc.old)
+			newSchema := loadSchema(t, tc.new)
+
+			var actual []string
+			for _, change := range Compare(oldSchema, newSchema) {
+				actual = append(actual, change.String())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func loadSchema(t *testing.T, raw string) *model.Schema {
+	src := ast.Source{
+		Name:  "testcase",
+		Input: raw,
+	}
+	rawSchema, err := gqlparser.LoadSchema(&src)
+	assert.NoError(t, err)
+
+	s, err := model.MakeSchema(rawSchema)
+	assert.NoError(t, err)
+
+	s.FilterBuiltins()
+	return s
+}
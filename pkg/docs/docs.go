@@ -0,0 +1,432 @@
+// Package docs renders a schema as a set of interlinked Markdown pages, suitable for publishing as static
+// API documentation.
+package docs
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Page is a single rendered Markdown document. Path is relative to the root of the output directory.
+type Page struct {
+	Path    string
+	Content string
+}
+
+// IndexPath is the path of the index page, which links to all other pages.
+const IndexPath = "README.md"
+
+// typesDir is the directory containing one page per type.
+const typesDir = "types"
+
+// kindSections lists the sections of the index, in the order in which they appear.
+var kindSections = []struct {
+	kind  ast.DefinitionKind
+	title string
+	label string
+}{
+	{ast.Object, "Objects", "Object"},
+	{ast.Interface, "Interfaces", "Interface"},
+	{ast.Union, "Unions", "Union"},
+	{ast.Enum, "Enums", "Enum"},
+	{ast.InputObject, "Input objects", "Input object"},
+	{ast.Scalar, "Scalars", "Scalar"},
+}
+
+func kindLabel(kind ast.DefinitionKind) string {
+	for _, section := range kindSections {
+		if section.kind == kind {
+			return section.label
+		}
+	}
+	return string(kind)
+}
+
+// reference records a usage of a type by a field, argument, or input field.
+type reference struct {
+	typeName     string
+	fieldName    string
+	argumentName string
+}
+
+type renderer struct {
+	schema *model.Schema
+
+	// references maps type names to the places where they are referenced.
+	references map[string][]reference
+
+	// memberOf maps object type names to the unions they are members of.
+	memberOf map[string][]string
+
+	// implementedBy maps interface names to the types which implement them.
+	implementedBy map[string][]string
+}
+
+// Render returns the pages documenting the given schema: an index page at IndexPath, with types grouped by
+// root operation and by kind, along with one page per type.
+func Render(s *model.Schema) ([]Page, error) {
+	r := newRenderer(s)
+
+	pages := []Page{{Path: IndexPath, Content: r.renderIndex()}}
+	for _, def := range s.Types.ToSortedList() {
+		content, err := r.renderType(def)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, Page{Path: typePagePath(def.Name), Content: content})
+	}
+
+	return pages, nil
+}
+
+func newRenderer(s *model.Schema) *renderer {
+	r := &renderer{
+		schema:        s,
+		references:    map[string][]reference{},
+		memberOf:      map[string][]string{},
+		implementedBy: map[string][]string{},
+	}
+
+	for _, def := range s.Types.ToSortedList() {
+		for _, f := range def.Fields {
+			r.addReference(f.Type, reference{typeName: def.Name, fieldName: f.Name})
+			for _, arg := range f.Arguments {
+				r.addReference(arg.Type, reference{typeName: def.Name, fieldName: f.Name, argumentName: arg.Name})
+			}
+		}
+
+		for _, iface := range def.Interfaces {
+			r.implementedBy[iface] = append(r.implementedBy[iface], def.Name)
+		}
+
+		if def.Kind == ast.Union {
+			for _, member := range def.PossibleTypes {
+				r.memberOf[member] = append(r.memberOf[member], def.Name)
+			}
+		}
+	}
+
+	return r
+}
+
+func (r *renderer) addReference(t *model.Type, ref reference) {
+	name := t.Unwrap().Name
+	r.references[name] = append(r.references[name], ref)
+}
+
+func typePagePath(name string) string {
+	return path.Join(typesDir, name+".md")
+}
+
+// anchor returns the Markdown heading anchor for the given field name.
+func anchor(name string) string {
+	return strings.ToLower(name)
+}
+
+// typeLink returns a link to the page for the named type, relative to a page in the given directory, or just
+// the type name if it has no page (for example, because it is a built-in type which has been filtered out).
+func (r *renderer) typeLink(name, fromDir string) string {
+	if _, ok := r.schema.Types[name]; !ok {
+		return name
+	}
+	return fmt.Sprintf("[%s](%s)", name, relativePath(fromDir, typePagePath(name)))
+}
+
+func (r *renderer) fieldLink(typeName, fieldName, fromDir string) string {
+	return fmt.Sprintf("[%s.%s](%s#%s)", typeName, fieldName, relativePath(fromDir, typePagePath(typeName)), anchor(fieldName))
+}
+
+func relativePath(fromDir, target string) string {
+	if fromDir == typesDir {
+		return path.Base(target)
+	}
+	return target
+}
+
+// typeRef renders a possibly-wrapped type reference, with the named type linked to its page.
+func (r *renderer) typeRef(t *model.Type, fromDir string) string {
+	switch t.Kind {
+	case model.NonNullKind:
+		return r.typeRef(t.OfType, fromDir) + "!"
+	case model.ListKind:
+		return `\[` + r.typeRef(t.OfType, fromDir) + `\]`
+	default:
+		return r.typeLink(t.Name, fromDir)
+	}
+}
+
+func (r *renderer) renderIndex() string {
+	var b strings.Builder
+	b.WriteString("# Schema\n\n")
+	if r.schema.Description != "" {
+		b.WriteString(r.schema.Description + "\n\n")
+	}
+
+	roots := []struct {
+		title    string
+		typeName string
+	}{
+		{"Queries", r.schema.QueryTypeName},
+		{"Mutations", r.schema.MutationTypeName},
+		{"Subscriptions", r.schema.SubscriptionTypeName},
+	}
+	for _, root := range roots {
+		def := r.schema.Types[root.typeName]
+		if def == nil || len(def.Fields) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "## %s\n\n", root.title)
+		b.WriteString("| Field | Type | Description |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for _, f := range def.Fields {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", r.fieldLink(def.Name, f.Name, ""), r.typeRef(f.Type, ""), tableCell(summary(f.Description)))
+		}
+		b.WriteString("\n")
+	}
+
+	defs := r.schema.Types.ToSortedList()
+	for _, section := range kindSections {
+		var entries []string
+		for _, def := range defs {
+			if def.Kind != section.kind {
+				continue
+			}
+			entry := "- " + r.typeLink(def.Name, "")
+			if desc := summary(def.Description); desc != "" {
+				entry += ": " + desc
+			}
+			entries = append(entries, entry)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "## %s\n\n", section.title)
+		b.WriteString(strings.Join(entries, "\n") + "\n\n")
+	}
+
+	if len(r.schema.Directives) > 0 {
+		b.WriteString("## Directives\n\n")
+		for _, d := range r.schema.Directives {
+			fmt.Fprintf(&b, "### @%s\n\n", d.Name)
+			fmt.Fprintf(&b, "```graphql\n%s\n```\n\n", formatDirectiveDefinitionSdl(d))
+			if d.Description != "" {
+				b.WriteString(d.Description + "\n\n")
+			}
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func (r *renderer) renderType(def *model.Definition) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", def.Name)
+	fmt.Fprintf(&b, "_%s_\n\n", kindLabel(def.Kind))
+	if def.Description != "" {
+		b.WriteString(def.Description + "\n\n")
+	}
+
+	if len(def.Directives) > 0 {
+		directives, err := formatDirectiveListAsCode(def.Directives)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "**Directives:** %s\n\n", directives)
+	}
+
+	r.writeTypeLinks(&b, "Implements", def.Interfaces)
+	switch def.Kind {
+	case ast.Interface:
+		r.writeTypeLinks(&b, "Implemented by", r.implementedBy[def.Name])
+	case ast.Union:
+		r.writeTypeLinks(&b, "Possible types", def.PossibleTypes)
+	}
+	r.writeTypeLinks(&b, "Member of", r.memberOf[def.Name])
+
+	if len(def.Fields) > 0 {
+		title := "Fields"
+		if def.Kind == ast.InputObject {
+			title = "Input fields"
+		}
+		fmt.Fprintf(&b, "## %s\n\n", title)
+		for _, f := range def.Fields {
+			if err := r.writeField(&b, f); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if len(def.EnumValues) > 0 {
+		b.WriteString("## Values\n\n")
+		for _, ev := range def.EnumValues {
+			entry := fmt.Sprintf("- `%s`", ev.Name)
+			if ev.Description != "" {
+				entry += ": " + oneLine(ev.Description)
+			}
+			if reason, ok := ev.Directives.DeprecationReason(); ok {
+				entry += fmt.Sprintf(" **Deprecated:** %s", oneLine(reason))
+			}
+			if otherDirectives := withoutDeprecated(ev.Directives); len(otherDirectives) > 0 {
+				directives, err := formatDirectiveListAsCode(otherDirectives)
+				if err != nil {
+					return "", err
+				}
+				entry += " " + directives
+			}
+			b.WriteString(entry + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if refs := r.references[def.Name]; len(refs) > 0 {
+		b.WriteString("## Used by\n\n")
+		for _, ref := range refs {
+			link := r.fieldLink(ref.typeName, ref.fieldName, typesDir)
+			if ref.argumentName != "" {
+				link = fmt.Sprintf("%s (argument `%s`)", link, ref.argumentName)
+			}
+			b.WriteString("- " + link + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n", nil
+}
+
+func (r *renderer) writeTypeLinks(b *strings.Builder, title string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	var links []string
+	for _, name := range names {
+		links = append(links, r.typeLink(name, typesDir))
+	}
+	fmt.Fprintf(b, "**%s:** %s\n\n", title, strings.Join(links, ", "))
+}
+
+func (r *renderer) writeField(b *strings.Builder, f *model.FieldDefinition) error {
+	fmt.Fprintf(b, "### %s\n\n", f.Name)
+	fmt.Fprintf(b, "**Type:** %s\n\n", r.typeRef(f.Type, typesDir))
+
+	if f.DefaultValue != nil {
+		defaultValue, err := model.FormatValue(f.DefaultValue)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "**Default:** `%s`\n\n", defaultValue)
+	}
+
+	if f.Description != "" {
+		b.WriteString(f.Description + "\n\n")
+	}
+
+	if reason, ok := f.Directives.DeprecationReason(); ok {
+		fmt.Fprintf(b, "> **Deprecated:** %s\n\n", oneLine(reason))
+	}
+
+	if otherDirectives := withoutDeprecated(f.Directives); len(otherDirectives) > 0 {
+		directives, err := formatDirectiveListAsCode(otherDirectives)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "**Directives:** %s\n\n", directives)
+	}
+
+	if len(f.Arguments) > 0 {
+		b.WriteString("| Argument | Type | Default | Description |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, arg := range f.Arguments {
+			defaultValue := ""
+			if arg.DefaultValue != nil {
+				formatted, err := model.FormatValue(arg.DefaultValue)
+				if err != nil {
+					return err
+				}
+				defaultValue = "`" + formatted + "`"
+			}
+
+			description := arg.Description
+			if reason, ok := arg.Directives.DeprecationReason(); ok {
+				description = strings.TrimSpace(description + " **Deprecated:** " + reason)
+			}
+
+			fmt.Fprintf(b, "| `%s` | %s | %s | %s |\n", arg.Name, r.typeRef(arg.Type, typesDir), tableCell(defaultValue), tableCell(description))
+		}
+		b.WriteString("\n")
+	}
+
+	return nil
+}
+
+func withoutDeprecated(directives model.DirectiveList) model.DirectiveList {
+	var result model.DirectiveList
+	for _, d := range directives {
+		if d.Name != "deprecated" {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// summary returns the first paragraph of the given description, on a single line.
+func summary(description string) string {
+	paragraph, _, _ := strings.Cut(strings.TrimSpace(description), "\n\n")
+	return oneLine(paragraph)
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// tableCell escapes the given string for use within a Markdown table cell.
+func tableCell(s string) string {
+	return strings.ReplaceAll(oneLine(s), "|", `\|`)
+}
+
+// formatDirectiveDefinitionSdl returns the full SDL definition of the given directive. Unlike the compact form
+// printed by 'ls directives', it includes default values and the repeatable keyword.
+func formatDirectiveDefinitionSdl(d *model.DirectiveDefinition) string {
+	var args []string
+	for _, arg := range d.Arguments {
+		formatted := fmt.Sprintf("%s: %s", arg.Name, arg.Type)
+		if arg.DefaultValue != nil {
+			if defaultValue, err := model.FormatValue(arg.DefaultValue); err == nil {
+				formatted += " = " + defaultValue
+			}
+		}
+		args = append(args, formatted)
+	}
+
+	result := "directive @" + d.Name
+	if len(args) > 0 {
+		result += "(" + strings.Join(args, ", ") + ")"
+	}
+	if d.IsRepeatable {
+		result += " repeatable"
+	}
+
+	var locations []string
+	for _, loc := range d.Locations {
+		locations = append(locations, string(loc))
+	}
+	return result + " on " + strings.Join(locations, " | ")
+}
+
+// formatDirectiveListAsCode returns the given directives separated by spaces, each as a Markdown code span.
+func formatDirectiveListAsCode(dl model.DirectiveList) (string, error) {
+	var formatted []string
+	for _, d := range dl {
+		directive, err := d.Format()
+		if err != nil {
+			return "", err
+		}
+		formatted = append(formatted, "`"+directive+"`")
+	}
+	return strings.Join(formatted, " "), nil
+}
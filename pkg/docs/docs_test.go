@@ -0,0 +1,255 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testSchema = `
+"Fruit, and things to do with them."
+schema {
+	query: Query
+	mutation: Mutation
+}
+
+"Marks a field as requiring the given scope."
+directive @scope(name: String!) on FIELD_DEFINITION | ENUM_VALUE
+
+type Query {
+	"""
+	Look up a fruit by name.
+
+	Returns null if there is no such fruit.
+	"""
+	fruit(name: String! = "apple", "Include | rotten fruit?" rotten: Boolean): Fruit
+	edibles: [Edible!]! @deprecated(reason: "Use fruit instead.")
+}
+
+type Mutation {
+	eat(filter: Filter): Edible @scope(name: "eat")
+}
+
+"Something that can be eaten."
+interface Edible {
+	calories: Int
+}
+
+type Apple implements Edible {
+	calories: Int
+	variety: AppleVariety @deprecated
+}
+
+type Orange implements Edible {
+	calories: Int
+}
+
+union Fruit = Apple | Orange
+
+enum AppleVariety {
+	"The best one."
+	FUJI
+	GALA @deprecated(reason: "Too sweet.") @scope(name: "gala")
+}
+
+input Filter {
+	limit: Int = 10
+}
+`
+
+func TestRender(t *testing.T) {
+	raw, err := gqlparser.LoadSchema(&ast.Source{Name: "testcase", Input: testSchema})
+	assert.NoError(t, err)
+	s, err := model.MakeSchema(raw)
+	assert.NoError(t, err)
+	s.FilterBuiltins()
+
+	pages, err := Render(s)
+	assert.NoError(t, err)
+
+	actual := map[string]string{}
+	var paths []string
+	for _, page := range pages {
+		actual[page.Path] = page.Content
+		paths = append(paths, page.Path)
+	}
+
+	assert.Equal(t, []string{
+		"README.md",
+		"types/Apple.md",
+		"types/AppleVariety.md",
+		"types/Edible.md",
+		"types/Filter.md",
+		"types/Fruit.md",
+		"types/Mutation.md",
+		"types/Orange.md",
+		"types/Query.md",
+	}, paths)
+
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{
+			path: "README.md",
+			expected: "# Schema\n\n" +
+				"Fruit, and things to do with them.\n\n" +
+				"## Queries\n\n" +
+				"| Field | Type | Description |\n" +
+				"| --- | --- | --- |\n" +
+				"| [Query.fruit](types/Query.md#fruit) | [Fruit](types/Fruit.md) | Look up a fruit by name. |\n" +
+				"| [Query.edibles](types/Query.md#edibles) | \\[[Edible](types/Edible.md)!\\]! |  |\n\n" +
+				"## Mutations\n\n" +
+				"| Field | Type | Description |\n" +
+				"| --- | --- | --- |\n" +
+				"| [Mutation.eat](types/Mutation.md#eat) | [Edible](types/Edible.md) |  |\n\n" +
+				"## Objects\n\n" +
+				"- [Apple](types/Apple.md)\n" +
+				"- [Mutation](types/Mutation.md)\n" +
+				"- [Orange](types/Orange.md)\n" +
+				"- [Query](types/Query.md)\n\n" +
+				"## Interfaces\n\n" +
+				"- [Edible](types/Edible.md): Something that can be eaten.\n\n" +
+				"## Unions\n\n" +
+				"- [Fruit](types/Fruit.md)\n\n" +
+				"## Enums\n\n" +
+				"- [AppleVariety](types/AppleVariety.md)\n\n" +
+				"## Input objects\n\n" +
+				"- [Filter](types/Filter.md)\n\n" +
+				"## Directives\n\n" +
+				"### @scope\n\n" +
+				"```graphql\n" +
+				"directive @scope(name: String!) on FIELD_DEFINITION | ENUM_VALUE\n" +
+				"```\n\n" +
+				"Marks a field as requiring the given scope.\n",
+		},
+		{
+			path: "types/Query.md",
+			expected: "# Query\n\n" +
+				"_Object_\n\n" +
+				"## Fields\n\n" +
+				"### fruit\n\n" +
+				"**Type:** [Fruit](Fruit.md)\n\n" +
+				"Look up a fruit by name.\n\n" +
+				"Returns null if there is no such fruit.\n\n" +
+				"| Argument | Type | Default | Description |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| `name` | String! | `\"apple\"` |  |\n" +
+				"| `rotten` | Boolean |  | Include \\| rotten fruit? |\n\n" +
+				"### edibles\n\n" +
+				"**Type:** \\[[Edible](Edible.md)!\\]!\n\n" +
+				"> **Deprecated:** Use fruit instead.\n",
+		},
+		{
+			path: "types/Apple.md",
+			expected: "# Apple\n\n" +
+				"_Object_\n\n" +
+				"**Implements:** [Edible](Edible.md)\n\n" +
+				"**Member of:** [Fruit](Fruit.md)\n\n" +
+				"## Fields\n\n" +
+				"### calories\n\n" +
+				"**Type:** Int\n\n" +
+				"### variety\n\n" +
+				"**Type:** [AppleVariety](AppleVariety.md)\n\n" +
+				"> **Deprecated:** No longer supported\n",
+		},
+		{
+			path: "types/Edible.md",
+			expected: "# Edible\n\n" +
+				"_Interface_\n\n" +
+				"Something that can be eaten.\n\n" +
+				"**Implemented by:** [Apple](Apple.md), [Orange](Orange.md)\n\n" +
+				"## Fields\n\n" +
+				"### calories\n\n" +
+				"**Type:** Int\n\n" +
+				"## Used by\n\n" +
+				"- [Mutation.eat](Mutation.md#eat)\n" +
+				"- [Query.edibles](Query.md#edibles)\n",
+		},
+		{
+			path: "types/AppleVariety.md",
+			expected: "# AppleVariety\n\n" +
+				"_Enum_\n\n" +
+				"## Values\n\n" +
+				"- `FUJI`: The best one.\n" +
+				"- `GALA` **Deprecated:** Too sweet. `@scope(name: \"gala\")`\n\n" +
+				"## Used by\n\n" +
+				"- [Apple.variety](Apple.md#variety)\n",
+		},
+		{
+			path: "types/Filter.md",
+			expected: "# Filter\n\n" +
+				"_Input object_\n\n" +
+				"## Input fields\n\n" +
+				"### limit\n\n" +
+				"**Type:** Int\n\n" +
+				"**Default:** `10`\n\n" +
+				"## Used by\n\n" +
+				"- [Mutation.eat](Mutation.md#eat) (argument `filter`)\n",
+		},
+		{
+			path: "types/Mutation.md",
+			expected: "# Mutation\n\n" +
+				"_Object_\n\n" +
+				"## Fields\n\n" +
+				"### eat\n\n" +
+				"**Type:** [Edible](Edible.md)\n\n" +
+				"**Directives:** `@scope(name: \"eat\")`\n\n" +
+				"| Argument | Type | Default | Description |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| `filter` | [Filter](Filter.md) |  |  |\n",
+		},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, actual[tc.path])
+		})
+	}
+}
+
+func TestRenderValuesAsGraphQL(t *testing.T) {
+	raw, err := gqlparser.LoadSchema(&ast.Source{Name: "testcase", Input: `
+directive @cache(level: Level = LOW, opts: CacheOptions = {level: HIGH, tags: ["a", "b"]}) on FIELD_DEFINITION
+
+enum Level {
+	LOW
+	HIGH
+}
+
+input CacheOptions {
+	level: Level = HIGH
+	tags: [String!]
+}
+
+type Query {
+	item(level: Level = LOW): String @cache(level: HIGH)
+}
+`})
+	assert.NoError(t, err)
+	s, err := model.MakeSchema(raw)
+	assert.NoError(t, err)
+	s.FilterBuiltins()
+
+	pages, err := Render(s)
+	assert.NoError(t, err)
+
+	actual := map[string]string{}
+	for _, page := range pages {
+		actual[page.Path] = page.Content
+	}
+
+	directiveSdl := `directive @cache(level: Level = LOW, opts: CacheOptions = {level:HIGH,tags:["a","b"]}) on FIELD_DEFINITION`
+	assert.Contains(t, actual["README.md"], "```graphql\n"+directiveSdl+"\n```")
+	assert.Contains(t, actual["types/Query.md"], "| `level` | [Level](Level.md) | `LOW` |  |")
+	assert.Contains(t, actual["types/Query.md"], "**Directives:** `@cache(level: HIGH)`")
+	assert.Contains(t, actual["types/CacheOptions.md"], "**Default:** `HIGH`")
+
+	// The directive definition should be valid SDL in its own right.
+	_, err = gqlparser.LoadSchema(&ast.Source{Name: "directive", Input: directiveSdl + `
+enum Level { LOW HIGH }
+input CacheOptions { level: Level tags: [String!] }
+type Query { item: String }`})
+	assert.NoError(t, err)
+}
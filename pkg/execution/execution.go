@@ -0,0 +1,394 @@
+// Package execution implements a minimal GraphQL executor, which can answer introspection queries
+// (those using the __schema, __type, and __typename meta-fields) against a schema loaded from SDL,
+// without any network access.
+//
+// Fields other than the introspection meta-fields resolve to null, unless a Resolver is supplied.
+package execution
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// Request is a GraphQL request, in the format used for GraphQL over HTTP.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is a GraphQL response. Data is omitted if the request could not be executed at all, for
+// example due to a validation error, but is otherwise always present, even if it is null.
+type Response struct {
+	Data   any
+	Errors gqlerror.List
+
+	// executed records whether execution started, in which case Data must be included even if it is null.
+	executed bool
+}
+
+func (r Response) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Data   *any          `json:"data,omitempty"`
+		Errors gqlerror.List `json:"errors,omitempty"`
+	}{
+		Errors: r.Errors,
+	}
+	if r.executed || r.Data != nil {
+		out.Data = &r.Data
+	}
+	return json.Marshal(out)
+}
+
+// errNullPropagation signals that a null value was produced in a non-null position, and must propagate
+// to the nearest nullable parent. The corresponding error will already have been recorded.
+var errNullPropagation = errors.New("null in non-null position")
+
+// Execute parses, validates, and executes the given request against the given schema.
+func Execute(schema *ast.Schema, req Request, opts ...Option) *Response {
+	doc, err := parser.ParseQuery(&ast.Source{Name: "query", Input: req.Query})
+	if err != nil {
+		return &Response{Errors: gqlerror.List{toGQLError(err)}}
+	}
+
+	if errs := validator.Validate(schema, doc); len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	return ExecuteDocument(schema, doc, req.OperationName, req.Variables, opts...)
+}
+
+// ExecuteDocument executes the named operation from the given document against the given schema. The
+// document must already have been validated against the schema. If operationName is empty, the document
+// must contain exactly one operation.
+func ExecuteDocument(schema *ast.Schema, doc *ast.QueryDocument, operationName string, variables map[string]any, opts ...Option) *Response {
+	op, err := selectOperation(doc, operationName)
+	if err != nil {
+		return &Response{Errors: gqlerror.List{err}}
+	}
+
+	coercedVariables, varErr := validator.VariableValues(schema, op, variables)
+	if varErr != nil {
+		return &Response{Errors: gqlerror.List{toGQLError(varErr)}}
+	}
+
+	var rootType *ast.Definition
+	switch op.Operation {
+	case ast.Mutation:
+		rootType = schema.Mutation
+	case ast.Subscription:
+		rootType = schema.Subscription
+	default:
+		rootType = schema.Query
+	}
+	if rootType == nil {
+		return &Response{Errors: gqlerror.List{gqlerror.Errorf("schema does not support %s operations", op.Operation)}}
+	}
+
+	e := &executor{
+		schema:    schema,
+		doc:       doc,
+		variables: coercedVariables,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	var data any
+	if result, err := e.executeSelectionSet(op.SelectionSet, rootType, nil, nil); err == nil {
+		data = result
+	}
+
+	return &Response{
+		Data:     data,
+		Errors:   e.errors,
+		executed: true,
+	}
+}
+
+func selectOperation(doc *ast.QueryDocument, operationName string) (*ast.OperationDefinition, *gqlerror.Error) {
+	if operationName == "" {
+		if len(doc.Operations) != 1 {
+			return nil, gqlerror.Errorf("an operation name is required when the document contains %d operations", len(doc.Operations))
+		}
+		return doc.Operations[0], nil
+	}
+
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		return nil, gqlerror.Errorf("unknown operation named '%s'", operationName)
+	}
+	return op, nil
+}
+
+func toGQLError(err error) *gqlerror.Error {
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) {
+		return gqlErr
+	}
+	return gqlerror.Wrap(err)
+}
+
+type executor struct {
+	schema    *ast.Schema
+	doc       *ast.QueryDocument
+	variables map[string]any
+	resolver  Resolver
+	errors    gqlerror.List
+}
+
+func (e *executor) addError(path ast.Path, field *ast.Field, format string, args ...any) {
+	err := gqlerror.ErrorPathf(copyPath(path), format, args...)
+	if field != nil && field.Position != nil {
+		err.Locations = []gqlerror.Location{{Line: field.Position.Line, Column: field.Position.Column}}
+	}
+	e.errors = append(e.errors, err)
+}
+
+// executeSelectionSet executes the given selection set against the given value of the given object type.
+func (e *executor) executeSelectionSet(ss ast.SelectionSet, objectType *ast.Definition, value any, path ast.Path) (*OrderedMap, error) {
+	result := NewOrderedMap()
+	grouped := NewOrderedMap()
+	e.collectFields(objectType, ss, grouped, map[string]bool{})
+
+	for _, key := range grouped.Keys() {
+		fields, _ := grouped.Get(key)
+		fieldValue, err := e.executeField(objectType, value, fields.([]*ast.Field), append(path, ast.PathName(key)))
+		if err != nil {
+			return nil, err
+		}
+		result.Set(key, fieldValue)
+	}
+
+	return result, nil
+}
+
+// collectFields groups the fields in the given selection set which apply to the given object type by their
+// response keys, following fragments and respecting @skip and @include.
+func (e *executor) collectFields(objectType *ast.Definition, ss ast.SelectionSet, grouped *OrderedMap, visitedFragments map[string]bool) {
+	for _, sel := range ss {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if !e.shouldInclude(sel.Directives) {
+				continue
+			}
+			key := sel.Alias
+			if key == "" {
+				key = sel.Name
+			}
+			existing, _ := grouped.Get(key)
+			fields, _ := existing.([]*ast.Field)
+			grouped.Set(key, append(fields, sel))
+		case *ast.InlineFragment:
+			if !e.shouldInclude(sel.Directives) || !e.typeConditionApplies(sel.TypeCondition, objectType) {
+				continue
+			}
+			e.collectFields(objectType, sel.SelectionSet, grouped, visitedFragments)
+		case *ast.FragmentSpread:
+			if !e.shouldInclude(sel.Directives) || visitedFragments[sel.Name] {
+				continue
+			}
+			visitedFragments[sel.Name] = true
+			fragment := e.doc.Fragments.ForName(sel.Name)
+			if fragment == nil || !e.typeConditionApplies(fragment.TypeCondition, objectType) {
+				continue
+			}
+			e.collectFields(objectType, fragment.SelectionSet, grouped, visitedFragments)
+		}
+	}
+}
+
+func (e *executor) shouldInclude(directives ast.DirectiveList) bool {
+	if d := directives.ForName("skip"); d != nil {
+		if skip, _ := d.ArgumentMap(e.variables)["if"].(bool); skip {
+			return false
+		}
+	}
+	if d := directives.ForName("include"); d != nil {
+		if include, _ := d.ArgumentMap(e.variables)["if"].(bool); !include {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *executor) typeConditionApplies(typeCondition string, objectType *ast.Definition) bool {
+	if typeCondition == "" || typeCondition == objectType.Name {
+		return true
+	}
+	conditionType := e.schema.Types[typeCondition]
+	if conditionType == nil {
+		return false
+	}
+	for _, possibleType := range e.schema.GetPossibleTypes(conditionType) {
+		if possibleType.Name == objectType.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *executor) executeField(objectType *ast.Definition, parent any, fields []*ast.Field, path ast.Path) (any, error) {
+	field := fields[0]
+	if field.Name == "__typename" {
+		return objectType.Name, nil
+	}
+
+	fieldDef := objectType.Fields.ForName(field.Name)
+	if fieldDef == nil {
+		e.addError(path, field, "unknown field '%s' on type '%s'", field.Name, objectType.Name)
+		return nil, nil
+	}
+
+	args := map[string]any{}
+	if field.Definition != nil {
+		args = field.ArgumentMap(e.variables)
+	}
+
+	value, err := e.resolveField(parent, FieldInfo{
+		ParentType: objectType,
+		Field:      fieldDef,
+		Args:       args,
+		Path:       copyPath(path),
+	})
+	if err != nil {
+		e.addError(path, field, "%s", err.Error())
+		if fieldDef.Type.NonNull {
+			return nil, errNullPropagation
+		}
+		return nil, nil
+	}
+
+	return e.completeValue(fieldDef.Type, fields, value, path)
+}
+
+func (e *executor) resolveField(parent any, info FieldInfo) (any, error) {
+	if info.ParentType == e.schema.Query {
+		switch info.Field.Name {
+		case "__schema":
+			return &schemaValue{schema: e.schema}, nil
+		case "__type":
+			name, _ := info.Args["name"].(string)
+			def := e.schema.Types[name]
+			if def == nil {
+				return nil, nil
+			}
+			return &typeValue{schema: e.schema, def: def}, nil
+		}
+	}
+
+	if value, ok := parent.(introspectionValue); ok {
+		return value.resolve(info.Field.Name, info.Args)
+	}
+
+	if e.resolver != nil {
+		return e.resolver.ResolveField(parent, info)
+	}
+
+	return nil, nil
+}
+
+// completeValue converts the resolved value of a field into its serialized form, according to the
+// field's type.
+func (e *executor) completeValue(t *ast.Type, fields []*ast.Field, value any, path ast.Path) (any, error) {
+	if t.NonNull {
+		nullableType := *t
+		nullableType.NonNull = false
+		result, err := e.completeNullableValue(&nullableType, fields, value, path)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			e.addError(path, fields[0], "cannot return null for non-nullable field")
+			return nil, errNullPropagation
+		}
+		return result, nil
+	}
+
+	result, err := e.completeNullableValue(t, fields, value, path)
+	if err != nil {
+		// Null values in non-null positions propagate up to the nearest nullable position.
+		return nil, nil
+	}
+	return result, nil
+}
+
+func (e *executor) completeNullableValue(t *ast.Type, fields []*ast.Field, value any, path ast.Path) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if t.Elem != nil {
+		items, ok := value.([]any)
+		if !ok {
+			e.addError(path, fields[0], "expected a list value, got %T", value)
+			return nil, nil
+		}
+		result := make([]any, len(items))
+		for i, item := range items {
+			completed, err := e.completeValue(t.Elem, fields, item, append(path, ast.PathIndex(i)))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = completed
+		}
+		return result, nil
+	}
+
+	def := e.schema.Types[t.NamedType]
+	if def == nil {
+		return nil, fmt.Errorf("unknown type '%s'", t.NamedType)
+	}
+
+	switch def.Kind {
+	case ast.Scalar, ast.Enum:
+		return value, nil
+	case ast.Object:
+		return e.executeSubSelections(def, fields, value, path)
+	default:
+		objectType, err := e.resolveAbstractType(def, value)
+		if err != nil {
+			e.addError(path, fields[0], "%s", err.Error())
+			return nil, nil
+		}
+		return e.executeSubSelections(objectType, fields, value, path)
+	}
+}
+
+func (e *executor) executeSubSelections(objectType *ast.Definition, fields []*ast.Field, value any, path ast.Path) (any, error) {
+	var subSelections ast.SelectionSet
+	for _, f := range fields {
+		subSelections = append(subSelections, f.SelectionSet...)
+	}
+	return e.executeSelectionSet(subSelections, objectType, value, path)
+}
+
+// resolveAbstractType returns the concrete object type of the given value of the given interface or union type.
+func (e *executor) resolveAbstractType(abstractType *ast.Definition, value any) (*ast.Definition, error) {
+	if e.resolver == nil {
+		return nil, fmt.Errorf("cannot resolve the concrete type of a value of abstract type '%s'", abstractType.Name)
+	}
+
+	typeName, err := e.resolver.ResolveType(abstractType, value)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, possibleType := range e.schema.GetPossibleTypes(abstractType) {
+		if possibleType.Name == typeName {
+			return possibleType, nil
+		}
+	}
+
+	return nil, fmt.Errorf("type '%s' is not a possible type of '%s'", typeName, abstractType.Name)
+}
+
+func copyPath(path ast.Path) ast.Path {
+	return append(ast.Path{}, path...)
+}
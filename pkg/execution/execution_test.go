@@ -0,0 +1,197 @@
+package execution
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestExecuteIntrospection(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{
+		Name: "schema",
+		Input: `"The root query type."
+		type Query {
+			fruit(name: String = "apple"): Fruit
+			fruits: [Fruit!]! @deprecated
+			person: Person
+		}
+
+		type Person implements Named {
+			name: String!
+		}
+
+		interface Named {
+			name: String!
+		}
+
+		union Fruit = Apple | Orange
+
+		type Apple {
+			variety: AppleVariety
+		}
+
+		type Orange {
+			calories: Int
+		}
+
+		enum AppleVariety {
+			FUJI
+			GALA @deprecated(reason: "Too sweet.")
+		}
+
+		scalar URL @specifiedBy(url: "https://url.spec.whatwg.org/")`,
+	})
+
+	for _, tc := range []struct {
+		name      string
+		request   Request
+		expected  string
+		expectErr bool
+	}{
+		{
+			name: "type with fields",
+			request: Request{
+				Query: `{
+					__type(name: "Query") {
+						kind
+						name
+						description
+						fields { name type { kind ofType { kind ofType { kind name } } } }
+					}
+				}`,
+			},
+			expected: `{"data":{"__type":{"kind":"OBJECT","name":"Query","description":"The root query type.","fields":[
+				{"name":"fruit","type":{"kind":"UNION","ofType":null}},
+				{"name":"person","type":{"kind":"OBJECT","ofType":null}}
+			]}}}`,
+		},
+		{
+			name: "deprecated fields and enum values",
+			request: Request{
+				Query: `{
+					query: __type(name: "Query") { fields(includeDeprecated: true) { name isDeprecated deprecationReason } }
+					enum: __type(name: "AppleVariety") { enumValues(includeDeprecated: true) { name deprecationReason } }
+				}`,
+			},
+			expected: `{"data":{
+				"query":{"fields":[
+					{"name":"fruit","isDeprecated":false,"deprecationReason":null},
+					{"name":"fruits","isDeprecated":true,"deprecationReason":"No longer supported"},
+					{"name":"person","isDeprecated":false,"deprecationReason":null}
+				]},
+				"enum":{"enumValues":[{"name":"FUJI","deprecationReason":null},{"name":"GALA","deprecationReason":"Too sweet."}]}
+			}}`,
+		},
+		{
+			name: "arguments, possible types, interfaces, and scalars",
+			request: Request{
+				Query: `query Q($name: String!) {
+					query: __type(name: "Query") { fields { name args { name defaultValue } } }
+					union: __type(name: "Fruit") { possibleTypes { name } interfaces { name } }
+					iface: __type(name: "Named") { possibleTypes { name } }
+					person: __type(name: "Person") { interfaces { name } }
+					scalar: __type(name: $name) { specifiedByURL fields { name } }
+				}`,
+				Variables: map[string]any{"name": "URL"},
+			},
+			expected: `{"data":{
+				"query":{"fields":[{"name":"fruit","args":[{"name":"name","defaultValue":"\"apple\""}]},{"name":"person","args":[]}]},
+				"union":{"possibleTypes":[{"name":"Apple"},{"name":"Orange"}],"interfaces":null},
+				"iface":{"possibleTypes":[{"name":"Person"}]},
+				"person":{"interfaces":[{"name":"Named"}]},
+				"scalar":{"specifiedByURL":"https://url.spec.whatwg.org/","fields":null}
+			}}`,
+		},
+		{
+			name: "fragments, typename, and directives",
+			request: Request{
+				Query: `query Q($skip: Boolean!) {
+					__typename
+					__schema {
+						queryType { ...TypeName }
+						mutationType { ...TypeName }
+						... on __Schema @skip(if: $skip) { subscriptionType { name } }
+						directives @include(if: false) { name }
+					}
+				}
+
+				fragment TypeName on __Type { __typename name }`,
+				Variables: map[string]any{"skip": true},
+			},
+			expected: `{"data":{"__typename":"Query","__schema":{"queryType":{"__typename":"__Type","name":"Query"},"mutationType":null}}}`,
+		},
+		{
+			name: "unknown type",
+			request: Request{
+				Query: `{ __type(name: "Banana") { name } }`,
+			},
+			expected: `{"data":{"__type":null}}`,
+		},
+		{
+			name: "non-introspection fields",
+			request: Request{
+				Query: `{ person { name } }`,
+			},
+			expected: `{"data":{"person":null}}`,
+		},
+		{
+			name: "null propagated to the root",
+			request: Request{
+				Query: `{ fruits { __typename } }`,
+			},
+			expected:  `{"data":null,"errors":[{"message":"cannot return null for non-nullable field","locations":[{"line":1,"column":3}],"path":["fruits"]}]}`,
+			expectErr: true,
+		},
+		{
+			name: "validation error",
+			request: Request{
+				Query: `{ __type(name: "Query") { banana } }`,
+			},
+			expected:  `{"errors":[{"message":"Cannot query field \"banana\" on type \"__Type\".","locations":[{"line":1,"column":27}],"extensions":{"file":"query"}}]}`,
+			expectErr: true,
+		},
+		{
+			name: "missing operation name",
+			request: Request{
+				Query: `query A { __typename } query B { __typename }`,
+			},
+			expected:  `{"errors":[{"message":"an operation name is required when the document contains 2 operations"}]}`,
+			expectErr: true,
+		},
+		{
+			name: "selected operation",
+			request: Request{
+				Query:         `query A { a: __typename } query B { b: __typename }`,
+				OperationName: "B",
+			},
+			expected: `{"data":{"b":"Query"}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rsp := Execute(schema, tc.request)
+			if tc.expectErr {
+				assert.NotEmpty(t, rsp.Errors)
+			} else {
+				assert.Empty(t, rsp.Errors)
+			}
+
+			actual, err := json.Marshal(rsp)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap()
+	m.Set("zebra", 1)
+	m.Set("apple", []any{"a", nil})
+	m.Set("zebra", 2)
+
+	actual, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"zebra":2,"apple":["a",null]}`, string(actual))
+}
@@ -0,0 +1,85 @@
+package execution
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// NewHandler returns an http.Handler which serves GraphQL requests against the given schema, using the
+// given options. Requests may be made either via POST with a JSON body, or via GET with query, operationName,
+// and variables URL query parameters. As required by the GraphQL over HTTP spec, mutations may only be made
+// via POST, and other HTTP methods are rejected with a 405 status.
+func NewHandler(schema *ast.Schema, opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			w.Header().Set("Allow", "GET, POST")
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("unsupported HTTP method '%s'", r.Method))
+			return
+		}
+
+		req, err := parseRequest(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if r.Method == http.MethodGet && isMutation(req) {
+			w.Header().Set("Allow", "POST")
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("mutations must be sent via POST"))
+			return
+		}
+
+		writeResponse(w, http.StatusOK, Execute(schema, *req, opts...))
+	})
+}
+
+func parseRequest(r *http.Request) (*Request, error) {
+	var req Request
+	switch r.Method {
+	case http.MethodGet:
+		params := r.URL.Query()
+		req.Query = params.Get("query")
+		req.OperationName = params.Get("operationName")
+		if rawVariables := params.Get("variables"); rawVariables != "" {
+			if err := json.Unmarshal([]byte(rawVariables), &req.Variables); err != nil {
+				return nil, fmt.Errorf("failed to parse variables: %w", err)
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, fmt.Errorf("failed to parse request body: %w", err)
+		}
+	}
+
+	if req.Query == "" {
+		return nil, fmt.Errorf("no query given")
+	}
+
+	return &req, nil
+}
+
+// isMutation returns true if the operation selected by the given request is a mutation. Requests which can't
+// be parsed, or don't identify a single operation, are left for Execute to report.
+func isMutation(req *Request) bool {
+	doc, err := parser.ParseQuery(&ast.Source{Name: "query", Input: req.Query})
+	if err != nil {
+		return false
+	}
+	op, gqlErr := selectOperation(doc, req.OperationName)
+	return gqlErr == nil && op.Operation == ast.Mutation
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeResponse(w, status, &Response{Errors: gqlerror.List{gqlerror.Wrap(err)}})
+}
+
+func writeResponse(w http.ResponseWriter, status int, rsp *Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(rsp)
+}
@@ -0,0 +1,115 @@
+package execution
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestHandler(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: `type Query { hello: String } type Mutation { reset: Boolean }`})
+	server := httptest.NewServer(NewHandler(schema))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name           string
+		makeRequest    func() (*http.Response, error)
+		expectedStatus int
+		expectedAllow  string
+		expectedBody   string
+	}{
+		{
+			name: "post",
+			makeRequest: func() (*http.Response, error) {
+				return http.Post(server.URL, "application/json", strings.NewReader(`{"query": "query Q($n: String!) { __type(name: $n) { name } }", "variables": {"n": "Query"}}`))
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"__type":{"name":"Query"}}}`,
+		},
+		{
+			name: "get",
+			makeRequest: func() (*http.Response, error) {
+				params := url.Values{"query": {"{ __typename hello }"}}
+				return http.Get(server.URL + "?" + params.Encode())
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"__typename":"Query","hello":null}}`,
+		},
+		{
+			name: "malformed body",
+			makeRequest: func() (*http.Response, error) {
+				return http.Post(server.URL, "application/json", strings.NewReader(`{`))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"errors":[{"message":"failed to parse request body: unexpected EOF"}]}`,
+		},
+		{
+			name: "missing query",
+			makeRequest: func() (*http.Response, error) {
+				return http.Get(server.URL)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"errors":[{"message":"no query given"}]}`,
+		},
+		{
+			name: "post mutation",
+			makeRequest: func() (*http.Response, error) {
+				return http.Post(server.URL, "application/json", strings.NewReader(`{"query": "mutation { reset }"}`))
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"reset":null}}`,
+		},
+		{
+			name: "get mutation",
+			makeRequest: func() (*http.Response, error) {
+				params := url.Values{"query": {"query Q { hello } mutation M { reset }"}, "operationName": {"M"}}
+				return http.Get(server.URL + "?" + params.Encode())
+			},
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedAllow:  "POST",
+			expectedBody:   `{"errors":[{"message":"mutations must be sent via POST"}]}`,
+		},
+		{
+			name: "get query from a document with a mutation",
+			makeRequest: func() (*http.Response, error) {
+				params := url.Values{"query": {"query Q { hello } mutation M { reset }"}, "operationName": {"Q"}}
+				return http.Get(server.URL + "?" + params.Encode())
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"hello":null}}`,
+		},
+		{
+			name: "unsupported method",
+			makeRequest: func() (*http.Response, error) {
+				req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"query": "{ hello }"}`))
+				if err != nil {
+					return nil, err
+				}
+				return http.DefaultClient.Do(req)
+			},
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedAllow:  "GET, POST",
+			expectedBody:   `{"errors":[{"message":"unsupported HTTP method 'PUT'"}]}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rsp, err := tc.makeRequest()
+			assert.NoError(t, err)
+			defer func() { _ = rsp.Body.Close() }()
+
+			body, err := io.ReadAll(rsp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rsp.StatusCode)
+			assert.Equal(t, "application/json", rsp.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedAllow, rsp.Header.Get("Allow"))
+			assert.JSONEq(t, tc.expectedBody, string(body))
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package execution
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// The types in this file represent instances of the introspection types described in the GraphQL spec:
+// https://spec.graphql.org/October2021/#sec-Schema-Introspection.Schema-Introspection-Schema
+//
+// List values are returned as []any, and missing optional values as nil, in the form expected by the executor.
+
+type introspectionValue interface {
+	resolve(fieldName string, args map[string]any) (any, error)
+}
+
+func unknownIntrospectionField(typeName, fieldName string) error {
+	return fmt.Errorf("unknown field '%s' on introspection type '%s'", fieldName, typeName)
+}
+
+// optionalString returns nil for empty strings, since descriptions and similar fields are null when absent.
+func optionalString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// isIntrospectionName returns true for names reserved by the introspection system, like __typename, or __Type.
+func isIntrospectionName(name string) bool {
+	return strings.HasPrefix(name, "__")
+}
+
+type schemaValue struct {
+	schema *ast.Schema
+}
+
+func (v *schemaValue) resolve(fieldName string, _ map[string]any) (any, error) {
+	switch fieldName {
+	case "description":
+		return optionalString(v.schema.Description), nil
+	case "types":
+		var names []string
+		for name := range v.schema.Types {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var result []any
+		for _, name := range names {
+			result = append(result, &typeValue{schema: v.schema, def: v.schema.Types[name]})
+		}
+		return result, nil
+	case "queryType":
+		return v.namedType(v.schema.Query), nil
+	case "mutationType":
+		return v.namedType(v.schema.Mutation), nil
+	case "subscriptionType":
+		return v.namedType(v.schema.Subscription), nil
+	case "directives":
+		var names []string
+		for name := range v.schema.Directives {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var result []any
+		for _, name := range names {
+			result = append(result, &directiveValue{schema: v.schema, def: v.schema.Directives[name]})
+		}
+		return result, nil
+	default:
+		return nil, unknownIntrospectionField("__Schema", fieldName)
+	}
+}
+
+func (v *schemaValue) namedType(def *ast.Definition) any {
+	if def == nil {
+		return nil
+	}
+	return &typeValue{schema: v.schema, def: def}
+}
+
+// typeValue represents a __Type. Named types have def set, while list and non-null types have wrapped set.
+type typeValue struct {
+	schema  *ast.Schema
+	def     *ast.Definition
+	wrapped *ast.Type
+}
+
+func newTypeValue(schema *ast.Schema, t *ast.Type) *typeValue {
+	if t.NonNull || t.Elem != nil {
+		return &typeValue{schema: schema, wrapped: t}
+	}
+	return &typeValue{schema: schema, def: schema.Types[t.NamedType]}
+}
+
+func (v *typeValue) resolve(fieldName string, args map[string]any) (any, error) {
+	if v.wrapped != nil {
+		return v.resolveWrapped(fieldName)
+	}
+
+	def := v.def
+	switch fieldName {
+	case "kind":
+		return string(def.Kind), nil
+	case "name":
+		return def.Name, nil
+	case "description":
+		return optionalString(def.Description), nil
+	case "fields":
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			return nil, nil
+		}
+		includeDeprecated, _ := args["includeDeprecated"].(bool)
+		result := []any{}
+		for _, f := range def.Fields {
+			if isIntrospectionName(f.Name) || (!includeDeprecated && f.Directives.ForName("deprecated") != nil) {
+				continue
+			}
+			result = append(result, &fieldValue{schema: v.schema, def: f})
+		}
+		return result, nil
+	case "interfaces":
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			return nil, nil
+		}
+		result := []any{}
+		for _, name := range def.Interfaces {
+			if iface := v.schema.Types[name]; iface != nil {
+				result = append(result, &typeValue{schema: v.schema, def: iface})
+			}
+		}
+		return result, nil
+	case "possibleTypes":
+		switch def.Kind {
+		case ast.Union:
+			result := []any{}
+			for _, name := range def.Types {
+				if member := v.schema.Types[name]; member != nil {
+					result = append(result, &typeValue{schema: v.schema, def: member})
+				}
+			}
+			return result, nil
+		case ast.Interface:
+			var names []string
+			for _, possibleType := range v.schema.GetPossibleTypes(def) {
+				names = append(names, possibleType.Name)
+			}
+			sort.Strings(names)
+			result := []any{}
+			for _, name := range names {
+				result = append(result, &typeValue{schema: v.schema, def: v.schema.Types[name]})
+			}
+			return result, nil
+		default:
+			return nil, nil
+		}
+	case "enumValues":
+		if def.Kind != ast.Enum {
+			return nil, nil
+		}
+		includeDeprecated, _ := args["includeDeprecated"].(bool)
+		result := []any{}
+		for _, ev := range def.EnumValues {
+			if !includeDeprecated && ev.Directives.ForName("deprecated") != nil {
+				continue
+			}
+			result = append(result, &enumValueValue{schema: v.schema, def: ev})
+		}
+		return result, nil
+	case "inputFields":
+		if def.Kind != ast.InputObject {
+			return nil, nil
+		}
+		result := []any{}
+		for _, f := range def.Fields {
+			result = append(result, &inputValue{
+				schema:       v.schema,
+				name:         f.Name,
+				description:  f.Description,
+				t:            f.Type,
+				defaultValue: f.DefaultValue,
+			})
+		}
+		return result, nil
+	case "ofType":
+		return nil, nil
+	case "specifiedByURL":
+		if def.Kind != ast.Scalar {
+			return nil, nil
+		}
+		if d := def.Directives.ForName("specifiedBy"); d != nil {
+			if url := d.Arguments.ForName("url"); url != nil && url.Value != nil {
+				return url.Value.Raw, nil
+			}
+		}
+		return nil, nil
+	default:
+		return nil, unknownIntrospectionField("__Type", fieldName)
+	}
+}
+
+func (v *typeValue) resolveWrapped(fieldName string) (any, error) {
+	t := v.wrapped
+	switch fieldName {
+	case "kind":
+		if t.NonNull {
+			return "NON_NULL", nil
+		}
+		return "LIST", nil
+	case "ofType":
+		if t.NonNull {
+			nullable := *t
+			nullable.NonNull = false
+			return newTypeValue(v.schema, &nullable), nil
+		}
+		return newTypeValue(v.schema, t.Elem), nil
+	case "name", "description", "fields", "interfaces", "possibleTypes", "enumValues", "inputFields", "specifiedByURL":
+		return nil, nil
+	default:
+		return nil, unknownIntrospectionField("__Type", fieldName)
+	}
+}
+
+type fieldValue struct {
+	schema *ast.Schema
+	def    *ast.FieldDefinition
+}
+
+func (v *fieldValue) resolve(fieldName string, _ map[string]any) (any, error) {
+	switch fieldName {
+	case "name":
+		return v.def.Name, nil
+	case "description":
+		return optionalString(v.def.Description), nil
+	case "args":
+		result := []any{}
+		for _, arg := range v.def.Arguments {
+			result = append(result, &inputValue{
+				schema:       v.schema,
+				name:         arg.Name,
+				description:  arg.Description,
+				t:            arg.Type,
+				defaultValue: arg.DefaultValue,
+			})
+		}
+		return result, nil
+	case "type":
+		return newTypeValue(v.schema, v.def.Type), nil
+	case "isDeprecated":
+		return v.def.Directives.ForName("deprecated") != nil, nil
+	case "deprecationReason":
+		return deprecationReason(v.schema, v.def.Directives), nil
+	default:
+		return nil, unknownIntrospectionField("__Field", fieldName)
+	}
+}
+
+// inputValue represents an __InputValue, which may be either an argument, or an input object field.
+type inputValue struct {
+	schema       *ast.Schema
+	name         string
+	description  string
+	t            *ast.Type
+	defaultValue *ast.Value
+}
+
+func (v *inputValue) resolve(fieldName string, _ map[string]any) (any, error) {
+	switch fieldName {
+	case "name":
+		return v.name, nil
+	case "description":
+		return optionalString(v.description), nil
+	case "type":
+		return newTypeValue(v.schema, v.t), nil
+	case "defaultValue":
+		if v.defaultValue == nil {
+			return nil, nil
+		}
+		return v.defaultValue.String(), nil
+	default:
+		return nil, unknownIntrospectionField("__InputValue", fieldName)
+	}
+}
+
+type enumValueValue struct {
+	schema *ast.Schema
+	def    *ast.EnumValueDefinition
+}
+
+func (v *enumValueValue) resolve(fieldName string, _ map[string]any) (any, error) {
+	switch fieldName {
+	case "name":
+		return v.def.Name, nil
+	case "description":
+		return optionalString(v.def.Description), nil
+	case "isDeprecated":
+		return v.def.Directives.ForName("deprecated") != nil, nil
+	case "deprecationReason":
+		return deprecationReason(v.schema, v.def.Directives), nil
+	default:
+		return nil, unknownIntrospectionField("__EnumValue", fieldName)
+	}
+}
+
+type directiveValue struct {
+	schema *ast.Schema
+	def    *ast.DirectiveDefinition
+}
+
+func (v *directiveValue) resolve(fieldName string, _ map[string]any) (any, error) {
+	switch fieldName {
+	case "name":
+		return v.def.Name, nil
+	case "description":
+		return optionalString(v.def.Description), nil
+	case "locations":
+		result := []any{}
+		for _, loc := range v.def.Locations {
+			result = append(result, string(loc))
+		}
+		return result, nil
+	case "args":
+		result := []any{}
+		for _, arg := range v.def.Arguments {
+			result = append(result, &inputValue{
+				schema:       v.schema,
+				name:         arg.Name,
+				description:  arg.Description,
+				t:            arg.Type,
+				defaultValue: arg.DefaultValue,
+			})
+		}
+		return result, nil
+	case "isRepeatable":
+		return v.def.IsRepeatable, nil
+	default:
+		return nil, unknownIntrospectionField("__Directive", fieldName)
+	}
+}
+
+// deprecationReason returns the reason given in an applied @deprecated directive, falling back to the default
+// value from the directive's definition, or the default given by the GraphQL spec. It returns nil if the directive
+// is not applied.
+func deprecationReason(schema *ast.Schema, directives ast.DirectiveList) any {
+	d := directives.ForName("deprecated")
+	if d == nil {
+		return nil
+	}
+	if reason := d.Arguments.ForName("reason"); reason != nil && reason.Value != nil {
+		return reason.Value.Raw
+	}
+	if def := schema.Directives["deprecated"]; def != nil {
+		if reason := def.Arguments.ForName("reason"); reason != nil && reason.DefaultValue != nil {
+			return reason.DefaultValue.Raw
+		}
+	}
+	return model.DefaultDeprecationReason
+}
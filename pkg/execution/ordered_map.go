@@ -0,0 +1,62 @@
+package execution
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// OrderedMap is a JSON object which preserves the order in which its keys were added when serialized.
+// GraphQL responses list fields in the same order in which they were selected, so a plain map can't be used.
+type OrderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+// NewOrderedMap returns an empty OrderedMap.
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{
+		values: map[string]any{},
+	}
+}
+
+// Set sets the value for the given key, appending the key if it is not already present.
+func (m *OrderedMap) Set(key string, value any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get returns the value for the given key, and whether it was present.
+func (m *OrderedMap) Get(key string) (any, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Keys returns the keys of the map, in insertion order.
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		rawKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		rawValue, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(rawKey)
+		buf.WriteByte(':')
+		buf.Write(rawValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
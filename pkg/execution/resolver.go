@@ -0,0 +1,37 @@
+package execution
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Resolver supplies values for fields other than the introspection meta-fields, allowing the executor to
+// serve data for a schema.
+type Resolver interface {
+	// ResolveField returns the value of the given field on the given parent value. Leaf values are returned as
+	// is, list values must be returned as []any, and object values may be anything non-nil, which will then be
+	// passed as the parent when resolving their own fields.
+	ResolveField(parent any, info FieldInfo) (any, error)
+
+	// ResolveType returns the name of the concrete object type of the given value of the given abstract
+	// (interface or union) type.
+	ResolveType(abstractType *ast.Definition, value any) (string, error)
+}
+
+// FieldInfo describes the field being resolved.
+type FieldInfo struct {
+	ParentType *ast.Definition
+	Field      *ast.FieldDefinition
+	Args       map[string]any
+	Path       ast.Path
+}
+
+// Option configures the behavior of Execute and ExecuteDocument.
+type Option func(e *executor)
+
+// WithResolver sets the Resolver used for fields other than the introspection meta-fields. By default, such
+// fields resolve to null.
+func WithResolver(r Resolver) Option {
+	return func(e *executor) {
+		e.resolver = r
+	}
+}
@@ -0,0 +1,29 @@
+// Package explorer renders a schema as a self-contained, interactive HTML page, which can be viewed offline
+// in any web browser.
+package explorer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/model"
+)
+
+//go:embed explorer.html
+var pageTemplate string
+
+// schemaPlaceholder marks the location in the page template where the schema JSON is inserted.
+const schemaPlaceholder = "/*SCHEMA_JSON*/null"
+
+// Render returns an HTML document embedding the JSON representation of the given schema, along with the
+// scripts and styles needed to browse it. The document has no external dependencies.
+func Render(s *model.Schema) (string, error) {
+	// json.Marshal escapes <, >, and & by default, so the result is safe to embed within a <script> element.
+	schemaJSON, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Replace(pageTemplate, schemaPlaceholder, string(schemaJSON), 1), nil
+}
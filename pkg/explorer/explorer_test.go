@@ -0,0 +1,43 @@
+package explorer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestRender(t *testing.T) {
+	rawSchema := gqlparser.MustLoadSchema(&ast.Source{
+		Name: "schema",
+		Input: `type Query {
+			"Returns the person with the given name. Beware of </script> tags."
+			person(name: String!): Person
+		}
+
+		type Person {
+			name: String
+		}`,
+	})
+
+	s, err := model.MakeSchema(rawSchema)
+	assert.NoError(t, err)
+	s.FilterBuiltins()
+
+	page, err := Render(s)
+	assert.NoError(t, err)
+
+	assert.NotContains(t, page, schemaPlaceholder)
+	assert.Equal(t, 1, strings.Count(page, "</script>"), "schema content should not be able to close the script element")
+
+	// The embedded schema should round-trip to the same JSON as the json subcommand produces.
+	start := strings.Index(page, "const schema = ") + len("const schema = ")
+	end := strings.Index(page[start:], ";\n") + start
+	expected, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expected), page[start:end])
+}
@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/benweint/gquil/pkg/astutil"
+)
+
+// StronglyConnectedComponents returns the sets of types in the graph which are mutually reachable from one another,
+// found using Tarjan's algorithm. Only components which contain at least one cycle are returned, meaning that single
+// types are only included if they have a field which refers back to the same type.
+//
+// Each component is sorted by type name, and components are sorted from largest to smallest, and then by name.
+func (g *Graph) StronglyConnectedComponents() [][]string {
+	t := &tarjan{
+		g:       g,
+		index:   map[string]int{},
+		lowlink: map[string]int{},
+		onStack: map[string]bool{},
+	}
+
+	for _, name := range astutil.SortedKeys(g.nodes) {
+		if _, visited := t.index[name]; !visited {
+			t.strongConnect(name)
+		}
+	}
+
+	var result [][]string
+	for _, component := range t.components {
+		if len(component) == 1 && !g.hasSelfEdge(component[0]) {
+			continue
+		}
+		sort.Strings(component)
+		result = append(result, component)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if len(result[i]) != len(result[j]) {
+			return len(result[i]) > len(result[j])
+		}
+		return result[i][0] < result[j][0]
+	})
+
+	return result
+}
+
+func (g *Graph) hasSelfEdge(name string) bool {
+	for _, e := range g.edges[name] {
+		if e.dst.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+type tarjan struct {
+	g          *Graph
+	counter    int
+	index      map[string]int
+	lowlink    map[string]int
+	stack      []string
+	onStack    map[string]bool
+	components [][]string
+}
+
+func (t *tarjan) strongConnect(name string) {
+	t.index[name] = t.counter
+	t.lowlink[name] = t.counter
+	t.counter++
+	t.stack = append(t.stack, name)
+	t.onStack[name] = true
+
+	for _, e := range t.g.edges[name] {
+		next := e.dst.Name
+		if _, ok := t.g.nodes[next]; !ok {
+			continue
+		}
+		if _, visited := t.index[next]; !visited {
+			t.strongConnect(next)
+			t.lowlink[name] = min(t.lowlink[name], t.lowlink[next])
+		} else if t.onStack[next] {
+			t.lowlink[name] = min(t.lowlink[name], t.index[next])
+		}
+	}
+
+	if t.lowlink[name] != t.index[name] {
+		return
+	}
+
+	var component []string
+	for {
+		top := t.stack[len(t.stack)-1]
+		t.stack = t.stack[:len(t.stack)-1]
+		t.onStack[top] = false
+		component = append(component, top)
+		if top == name {
+			break
+		}
+	}
+	t.components = append(t.components, component)
+}
+
+// Cycles returns the elementary cycles in the graph: paths which start and end at the same type, without visiting
+// any other type more than once. If maxLength is greater than zero, only cycles with at most that many steps are
+// returned. Note that the number of elementary cycles in a large schema may be very large.
+//
+// Each cycle is returned once, starting from the lexically-first type it contains. Cycles are sorted by length,
+// and then lexically. As with Paths, hops from unions to their member types are treated as pass-through.
+func (g *Graph) Cycles(maxLength int) []Path {
+	var result []Path
+	for _, component := range g.StronglyConnectedComponents() {
+		// Only search within a single component, since no cycle can span multiple components.
+		for i, start := range component {
+			allowed := map[string]bool{}
+			for _, name := range component[i:] {
+				allowed[name] = true
+			}
+			f := &cycleFinder{
+				g:         g,
+				start:     start,
+				allowed:   allowed,
+				onPath:    map[string]bool{start: true},
+				maxLength: maxLength,
+			}
+			f.visit(start, nil)
+			result = append(result, f.found...)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if len(result[i]) != len(result[j]) {
+			return len(result[i]) < len(result[j])
+		}
+		return result[i].String() < result[j].String()
+	})
+
+	return result
+}
+
+type cycleFinder struct {
+	g         *Graph
+	start     string
+	allowed   map[string]bool
+	onPath    map[string]bool
+	maxLength int
+	found     []Path
+}
+
+func (f *cycleFinder) visit(typeName string, path Path) {
+	for _, e := range f.g.edges[typeName] {
+		next := e.dst.Name
+		if !f.allowed[next] {
+			continue
+		}
+
+		nextPath := path
+		switch e.kind {
+		case edgeKindField:
+			nextPath = append(path, PathStep{TypeName: e.src.Name, FieldName: e.field.Name})
+		case edgeKindArgument:
+			nextPath = append(path, PathStep{TypeName: e.src.Name, FieldName: e.field.Name, ArgumentName: e.argument.Name})
+		}
+
+		if f.maxLength > 0 && len(nextPath) > f.maxLength {
+			continue
+		}
+
+		if next == f.start {
+			f.found = append(f.found, append(Path{}, nextPath...))
+			continue
+		}
+
+		if f.onPath[next] {
+			continue
+		}
+
+		f.onPath[next] = true
+		f.visit(next, nextPath)
+		delete(f.onPath, next)
+	}
+}
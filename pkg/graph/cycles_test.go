@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const cyclesSchema = `type Query {
+	viewer: User
+	search(filter: SearchFilter): [SearchResult!]!
+}
+
+type User {
+	name: String
+	friends: [User!]!
+	repositories(first: Int): [Repository!]!
+	organization: Organization
+}
+
+type Organization {
+	members: [User!]!
+	pinned: SearchResult
+}
+
+type Repository {
+	owner: User
+}
+
+union SearchResult = User | Repository
+
+input SearchFilter {
+	and: [SearchFilter!]
+	name: String
+}`
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		opts     []GraphOption
+		expected [][]string
+	}{
+		{
+			name: "all edges",
+			expected: [][]string{
+				{"Organization", "Repository", "SearchResult", "User"},
+				{"SearchFilter"},
+			},
+		},
+		{
+			name: "output fields only",
+			opts: []GraphOption{WithOutputFieldsOnly()},
+			expected: [][]string{
+				{"Organization", "Repository", "SearchResult", "User"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := loadSchema(t, cyclesSchema)
+			s.FilterBuiltins()
+			g := MakeGraph(s, tc.opts...)
+			assert.Equal(t, tc.expected, g.StronglyConnectedComponents())
+		})
+	}
+}
+
+func TestCycles(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		opts      []GraphOption
+		maxLength int
+		expected  []string
+	}{
+		{
+			name: "all edges",
+			expected: []string{
+				"SearchFilter.and",
+				"User.friends",
+				"Organization.members -> User.organization",
+				"Organization.pinned -> User.organization",
+				"Repository.owner -> User.repositories",
+				"Organization.pinned -> Repository.owner -> User.organization",
+			},
+		},
+		{
+			name:      "max length",
+			maxLength: 1,
+			expected: []string{
+				"SearchFilter.and",
+				"User.friends",
+			},
+		},
+		{
+			name: "output fields only",
+			opts: []GraphOption{WithOutputFieldsOnly()},
+			expected: []string{
+				"User.friends",
+				"Organization.members -> User.organization",
+				"Organization.pinned -> User.organization",
+				"Organization.pinned -> Repository.owner -> User.organization",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := loadSchema(t, cyclesSchema)
+			s.FilterBuiltins()
+			g := MakeGraph(s, tc.opts...)
+
+			var actual []string
+			for _, cycle := range g.Cycles(tc.maxLength) {
+				actual = append(actual, cycle.String())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
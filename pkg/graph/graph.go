@@ -2,7 +2,6 @@ package graph
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/benweint/gquil/pkg/astutil"
@@ -15,6 +14,7 @@ type Graph struct {
 	edges              map[string][]*edge
 	interfacesAsUnions bool
 	renderBuiltins     bool
+	outputFieldsOnly   bool
 }
 
 func normalizeKind(kind ast.DefinitionKind, interfacesAsUnions bool) ast.DefinitionKind {
@@ -41,6 +41,14 @@ func WithBuiltins(renderBuiltins bool) GraphOption {
 	}
 }
 
+// WithOutputFieldsOnly causes edges to only be created for fields of output types which take no arguments.
+// Edges for arguments, for fields which take arguments, and for input object fields are omitted.
+func WithOutputFieldsOnly() GraphOption {
+	return func(g *Graph) {
+		g.outputFieldsOnly = true
+	}
+}
+
 func MakeGraph(s *model.Schema, opts ...GraphOption) *Graph {
 	g := &Graph{
 		nodes: s.Types,
@@ -55,8 +63,12 @@ func MakeGraph(s *model.Schema, opts ...GraphOption) *Graph {
 		var typeEdges []*edge
 		kind := normalizeKind(t.Kind, g.interfacesAsUnions)
 		switch kind {
-		case ast.Object, ast.InputObject:
+		case ast.Object:
 			typeEdges = g.makeFieldEdges(t)
+		case ast.InputObject:
+			if !g.outputFieldsOnly {
+				typeEdges = g.makeFieldEdges(t)
+			}
 		case ast.Union:
 			typeEdges = g.makeUnionEdges(t)
 		}
@@ -69,6 +81,9 @@ func MakeGraph(s *model.Schema, opts ...GraphOption) *Graph {
 func (g *Graph) makeFieldEdges(t *model.Definition) []*edge {
 	var result []*edge
 	for _, f := range t.Fields {
+		if g.outputFieldsOnly && len(f.Arguments) > 0 {
+			continue
+		}
 		fieldEdge := g.makeFieldEdge(t, f.Type.Unwrap(), f, nil)
 		if fieldEdge == nil {
 			continue
@@ -183,6 +198,73 @@ func (g *Graph) ReachableFrom(roots []*model.NameReference, maxDepth int) *Graph
 		}
 	}
 
+	return g.filter(seen)
+}
+
+// ReachableTo returns a new graph containing only the types and fields from which the given targets
+// are reachable, i.e. the reverse of ReachableFrom. Targets may be either types or fields.
+//
+// Target types are included along with all of their fields, while target fields are included on their own,
+// along with their host type. If maxDepth is greater than zero, only types within maxDepth hops of a target
+// (where the targets themselves are at depth 1) are included.
+func (g *Graph) ReachableTo(targets []*model.NameReference, maxDepth int) *Graph {
+	inbound := map[string][]*edge{}
+	for _, name := range astutil.SortedKeys(g.edges) {
+		for _, e := range g.edges[name] {
+			inbound[e.dst.Name] = append(inbound[e.dst.Name], e)
+		}
+	}
+
+	type queueEntry struct {
+		typeName string
+		depth    int
+	}
+
+	seen := referenceSet{}
+	var queue []queueEntry
+
+	for _, target := range targets {
+		targetType := g.nodes[target.TypeName]
+		if targetType == nil {
+			continue
+		}
+		if target.FieldName != "" {
+			seen[model.FieldNameReference(targetType.Name, target.FieldName)] = true
+		} else {
+			for _, f := range targetType.Fields {
+				seen[model.FieldNameReference(targetType.Name, f.Name)] = true
+			}
+		}
+		queue = append(queue, queueEntry{typeName: targetType.Name, depth: 1})
+	}
+
+	for len(queue) > 0 {
+		entry := queue[0]
+		queue = queue[1:]
+
+		key := model.TypeNameReference(entry.typeName)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if maxDepth > 0 && entry.depth >= maxDepth {
+			continue
+		}
+
+		for _, e := range inbound[entry.typeName] {
+			if e.field != nil {
+				seen[model.FieldNameReference(e.src.Name, e.field.Name)] = true
+			}
+			queue = append(queue, queueEntry{typeName: e.src.Name, depth: entry.depth + 1})
+		}
+	}
+
+	return g.filter(seen)
+}
+
+// filter returns a new graph containing only the types and fields in the given referenceSet, and the edges between them.
+func (g *Graph) filter(seen referenceSet) *Graph {
 	filteredNodes := model.DefinitionMap{}
 	for name, node := range g.nodes {
 		if seen.includesType(name) {
@@ -211,6 +293,7 @@ func (g *Graph) ReachableFrom(roots []*model.NameReference, maxDepth int) *Graph
 		edges:              filteredEdges,
 		interfacesAsUnions: g.interfacesAsUnions,
 		renderBuiltins:     g.renderBuiltins,
+		outputFieldsOnly:   g.outputFieldsOnly,
 	}
 }
 
@@ -222,7 +305,7 @@ func (g *Graph) ToDot() string {
 
 func (g *Graph) buildNodeDefs() []string {
 	var result []string
-	for _, name := range sortedKeys(g.nodes) {
+	for _, name := range astutil.SortedKeys(g.nodes) {
 		if astutil.IsBuiltinType(name) && !g.renderBuiltins {
 			continue
 		}
@@ -238,7 +321,7 @@ func (g *Graph) buildNodeDefs() []string {
 
 func (g *Graph) buildEdgeDefs() []string {
 	var result []string
-	for _, sourceNodeName := range sortedKeys(g.edges) {
+	for _, sourceNodeName := range astutil.SortedKeys(g.edges) {
 		edges := g.edges[sourceNodeName]
 		for _, edge := range edges {
 			if edge.dst.Kind == ast.Scalar {
@@ -280,15 +363,6 @@ func (g *Graph) buildEdgeDefs() []string {
 	return result
 }
 
-func sortedKeys[T any](m map[string]T) []string {
-	var result []string
-	for k := range m {
-		result = append(result, k)
-	}
-	sort.Strings(result)
-	return result
-}
-
 func (g *Graph) makeNodeLabel(node *model.Definition) string {
 	switch normalizeKind(node.Kind, g.interfacesAsUnions) {
 	case ast.Object:
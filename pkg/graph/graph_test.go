@@ -232,15 +232,7 @@ func TestReachableFrom(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			src := ast.Source{
-				Name:  "testcase",
-				Input: tc.schema,
-			}
-			rawSchema, err := gqlparser.LoadSchema(&src)
-			assert.NoError(t, err)
-
-			s, err := model.MakeSchema(rawSchema)
-			assert.NoError(t, err)
+			s := loadSchema(t, tc.schema)
 
 			roots, err := s.ResolveNames(tc.roots)
 			assert.NoError(t, err)
@@ -248,55 +240,272 @@ func TestReachableFrom(t *testing.T) {
 			g := MakeGraph(s)
 			trimmed := g.ReachableFrom(roots, tc.maxDepth)
 
-			var actualNodes []string
-			var actualEdges []edgeSpec
-			var actualFields []string
-
-			for _, node := range trimmed.nodes {
-				if node.Kind == ast.Scalar {
-					continue
-				}
-				actualNodes = append(actualNodes, node.Name)
-				for _, field := range node.Fields {
-					fieldId := node.Name + "." + field.Name
-					actualFields = append(actualFields, fieldId)
-				}
-			}
-
-			sort.Strings(actualNodes)
-			sort.Strings(actualFields)
-
-			assert.Equal(t, tc.expectedNodes, actualNodes)
-			assert.Equal(t, tc.expectedFields, actualFields)
-
-			for _, edges := range trimmed.edges {
-				for _, edge := range edges {
-					if edge.dst.Kind == ast.Scalar {
-						continue
-					}
-					fieldName := ""
-					if edge.field != nil {
-						fieldName = edge.field.Name
-					}
-					argName := ""
-					if edge.argument != nil {
-						argName = edge.argument.Name
-					}
-					actualEdge := edgeSpec{
-						srcType:   edge.src.Name,
-						dstType:   edge.dst.Name,
-						fieldName: fieldName,
-						argName:   argName,
-					}
-					actualEdges = append(actualEdges, actualEdge)
-				}
-			}
-
-			sort.Slice(actualEdges, func(i, j int) bool {
-				return strings.Compare(actualEdges[i].String(), actualEdges[j].String()) < 0
-			})
-
-			assert.Equal(t, tc.expectedEdges, actualEdges)
+			assertGraph(t, trimmed, tc.expectedNodes, tc.expectedFields, tc.expectedEdges)
 		})
 	}
 }
+
+func TestReachableTo(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		schema         string
+		targets        []string
+		expectedNodes  []string
+		expectedFields []string
+		expectedEdges  []edgeSpec
+		maxDepth       int
+	}{
+		{
+			name: "type target",
+			schema: `type Query {
+				alpha: Alpha
+				beta: Beta
+			}
+
+			type Alpha {
+				name: String
+				gamma: Gamma
+			}
+
+			type Beta {
+				name: String
+			}
+
+			type Gamma {
+				name: String
+			}`,
+			targets:        []string{"Gamma"},
+			expectedNodes:  []string{"Alpha", "Gamma", "Query"},
+			expectedFields: []string{"Alpha.gamma", "Gamma.name", "Query.alpha"},
+			expectedEdges: []edgeSpec{
+				{
+					srcType:   "Alpha",
+					dstType:   "Gamma",
+					fieldName: "gamma",
+				},
+				{
+					srcType:   "Query",
+					dstType:   "Alpha",
+					fieldName: "alpha",
+				},
+			},
+		},
+		{
+			name: "field target",
+			schema: `type Query {
+				person: Person
+				people: [Person]
+				count: Int
+			}
+
+			type Person {
+				name: String
+				ssn: String
+			}`,
+			targets:        []string{"Person.ssn"},
+			expectedNodes:  []string{"Person", "Query"},
+			expectedFields: []string{"Person.ssn", "Query.people", "Query.person"},
+			expectedEdges: []edgeSpec{
+				{
+					srcType:   "Query",
+					dstType:   "Person",
+					fieldName: "people",
+				},
+				{
+					srcType:   "Query",
+					dstType:   "Person",
+					fieldName: "person",
+				},
+			},
+		},
+		{
+			name: "unions and arguments",
+			schema: `type Query {
+				subject: Subject
+				search(filter: Filter): [String]
+			}
+
+			union Subject = Person | Organization
+
+			type Person {
+				name: String
+			}
+
+			type Organization {
+				name: String
+			}
+
+			input Filter {
+				person: PersonFilter
+			}
+
+			input PersonFilter {
+				name: String
+			}`,
+			targets:        []string{"Person", "PersonFilter"},
+			expectedNodes:  []string{"Filter", "Person", "PersonFilter", "Query", "Subject"},
+			expectedFields: []string{"Filter.person", "Person.name", "PersonFilter.name", "Query.search", "Query.subject"},
+			expectedEdges: []edgeSpec{
+				{
+					srcType:   "Filter",
+					dstType:   "PersonFilter",
+					fieldName: "person",
+				},
+				{
+					srcType:   "Query",
+					dstType:   "Filter",
+					fieldName: "search",
+					argName:   "filter",
+				},
+				{
+					srcType:   "Query",
+					dstType:   "Subject",
+					fieldName: "subject",
+				},
+				{
+					srcType: "Subject",
+					dstType: "Person",
+				},
+			},
+		},
+		{
+			name: "depth limited, shortest path wins",
+			schema: `type Query {
+				a: A
+				target: Target
+			}
+
+			type A {
+				b: B
+			}
+
+			type B {
+				target: Target
+			}
+
+			type Target {
+				name: String
+			}`,
+			targets:        []string{"Target"},
+			maxDepth:       2,
+			expectedNodes:  []string{"B", "Query", "Target"},
+			expectedFields: []string{"B.target", "Query.target", "Target.name"},
+			expectedEdges: []edgeSpec{
+				{
+					srcType:   "B",
+					dstType:   "Target",
+					fieldName: "target",
+				},
+				{
+					srcType:   "Query",
+					dstType:   "Target",
+					fieldName: "target",
+				},
+			},
+		},
+		{
+			name: "cycles",
+			schema: `type Person {
+				friends: [Person]
+				pet: Pet
+			}
+
+			type Pet {
+				name: String
+			}`,
+			targets:        []string{"Pet.name"},
+			expectedNodes:  []string{"Person", "Pet"},
+			expectedFields: []string{"Person.friends", "Person.pet", "Pet.name"},
+			expectedEdges: []edgeSpec{
+				{
+					srcType:   "Person",
+					dstType:   "Person",
+					fieldName: "friends",
+				},
+				{
+					srcType:   "Person",
+					dstType:   "Pet",
+					fieldName: "pet",
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := loadSchema(t, tc.schema)
+
+			targets, err := s.ResolveNames(tc.targets)
+			assert.NoError(t, err)
+
+			g := MakeGraph(s)
+			trimmed := g.ReachableTo(targets, tc.maxDepth)
+
+			assertGraph(t, trimmed, tc.expectedNodes, tc.expectedFields, tc.expectedEdges)
+		})
+	}
+}
+
+func loadSchema(t *testing.T, raw string) *model.Schema {
+	src := ast.Source{
+		Name:  "testcase",
+		Input: raw,
+	}
+	rawSchema, err := gqlparser.LoadSchema(&src)
+	assert.NoError(t, err)
+
+	s, err := model.MakeSchema(rawSchema)
+	assert.NoError(t, err)
+
+	return s
+}
+
+func assertGraph(t *testing.T, trimmed *Graph, expectedNodes, expectedFields []string, expectedEdges []edgeSpec) {
+	var actualNodes []string
+	var actualEdges []edgeSpec
+	var actualFields []string
+
+	for _, node := range trimmed.nodes {
+		if node.Kind == ast.Scalar {
+			continue
+		}
+		actualNodes = append(actualNodes, node.Name)
+		for _, field := range node.Fields {
+			fieldId := node.Name + "." + field.Name
+			actualFields = append(actualFields, fieldId)
+		}
+	}
+
+	sort.Strings(actualNodes)
+	sort.Strings(actualFields)
+
+	assert.Equal(t, expectedNodes, actualNodes)
+	assert.Equal(t, expectedFields, actualFields)
+
+	for _, edges := range trimmed.edges {
+		for _, edge := range edges {
+			if edge.dst.Kind == ast.Scalar {
+				continue
+			}
+			fieldName := ""
+			if edge.field != nil {
+				fieldName = edge.field.Name
+			}
+			argName := ""
+			if edge.argument != nil {
+				argName = edge.argument.Name
+			}
+			actualEdge := edgeSpec{
+				srcType:   edge.src.Name,
+				dstType:   edge.dst.Name,
+				fieldName: fieldName,
+				argName:   argName,
+			}
+			actualEdges = append(actualEdges, actualEdge)
+		}
+	}
+
+	sort.Slice(actualEdges, func(i, j int) bool {
+		return strings.Compare(actualEdges[i].String(), actualEdges[j].String()) < 0
+	})
+
+	assert.Equal(t, expectedEdges, actualEdges)
+}
@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ToMermaid renders the graph as a Mermaid class diagram.
+// See https://mermaid.js.org/syntax/classDiagram.html for details on the format.
+//
+// Each non-scalar type is rendered as a class, with fields (or enum values) as members. Edges from fields to
+// their types are rendered as associations, edges from arguments to their types as dependencies, and edges
+// from unions (or interfaces, with WithInterfacesAsUnions) to their possible types as inheritance.
+// Interface implementations are rendered as realizations.
+func (g *Graph) ToMermaid() string {
+	lines := []string{"classDiagram"}
+	lines = append(lines, g.buildMermaidClassDefs()...)
+	lines = append(lines, g.buildMermaidRelationDefs()...)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (g *Graph) buildMermaidClassDefs() []string {
+	var result []string
+	for _, name := range astutil.SortedKeys(g.nodes) {
+		if astutil.IsBuiltinType(name) && !g.renderBuiltins {
+			continue
+		}
+		node := g.nodes[name]
+		if node.Kind == ast.Scalar {
+			continue
+		}
+
+		members := g.makeMermaidMembers(node)
+		if annotation := mermaidAnnotation(node.Kind); annotation != "" {
+			members = append([]string{annotation}, members...)
+		}
+
+		if len(members) == 0 {
+			result = append(result, fmt.Sprintf("  class %s", node.Name))
+			continue
+		}
+
+		result = append(result, fmt.Sprintf("  class %s {", node.Name))
+		for _, member := range members {
+			result = append(result, "    "+member)
+		}
+		result = append(result, "  }")
+	}
+	return result
+}
+
+func (g *Graph) makeMermaidMembers(node *model.Definition) []string {
+	var result []string
+	switch node.Kind {
+	case ast.Enum:
+		for _, val := range node.EnumValues {
+			result = append(result, val.Name)
+		}
+	case ast.Object, ast.Interface, ast.InputObject:
+		for _, field := range node.Fields {
+			if !g.renderBuiltins && astutil.IsBuiltinField(field.Name) {
+				continue
+			}
+			if len(field.Arguments) == 0 {
+				result = append(result, fmt.Sprintf("%s: %s", field.Name, field.Type))
+				continue
+			}
+			var args []string
+			for _, arg := range field.Arguments {
+				args = append(args, fmt.Sprintf("%s: %s", arg.Name, arg.Type))
+			}
+			// Mermaid treats members with parentheses as methods, and renders the trailing text as the return type.
+			result = append(result, fmt.Sprintf("%s(%s) %s", field.Name, strings.Join(args, ", "), field.Type))
+		}
+	}
+	return result
+}
+
+func mermaidAnnotation(kind ast.DefinitionKind) string {
+	switch kind {
+	case ast.Interface:
+		return "<<interface>>"
+	case ast.Union:
+		return "<<union>>"
+	case ast.InputObject:
+		return "<<input>>"
+	case ast.Enum:
+		return "<<enumeration>>"
+	default:
+		return ""
+	}
+}
+
+func (g *Graph) buildMermaidRelationDefs() []string {
+	var result []string
+	for _, sourceNodeName := range astutil.SortedKeys(g.edges) {
+		for _, edge := range g.edges[sourceNodeName] {
+			if edge.dst.Kind == ast.Scalar {
+				continue
+			}
+
+			if !g.renderBuiltins {
+				if astutil.IsBuiltinType(edge.src.Name) {
+					continue
+				}
+				if edge.field != nil && astutil.IsBuiltinField(edge.field.Name) {
+					continue
+				}
+			}
+
+			switch edge.kind {
+			case edgeKindField:
+				result = append(result, fmt.Sprintf("  %s --> %s : %s", edge.src.Name, edge.dst.Name, edge.field.Name))
+			case edgeKindArgument:
+				result = append(result, fmt.Sprintf("  %s ..> %s : %s(%s)", edge.src.Name, edge.dst.Name, edge.field.Name, edge.argument.Name))
+			case edgeKindPossibleType:
+				result = append(result, fmt.Sprintf("  %s <|-- %s", edge.src.Name, edge.dst.Name))
+			}
+		}
+	}
+
+	// When interfaces are treated as unions, the relationships with their implementations are already
+	// represented by possible type edges.
+	if !g.interfacesAsUnions {
+		for _, name := range astutil.SortedKeys(g.nodes) {
+			node := g.nodes[name]
+			if !g.renderBuiltins && astutil.IsBuiltinType(name) {
+				continue
+			}
+			for _, iface := range node.Interfaces {
+				if _, ok := g.nodes[iface]; ok {
+					result = append(result, fmt.Sprintf("  %s <|.. %s", iface, node.Name))
+				}
+			}
+		}
+	}
+
+	return result
+}
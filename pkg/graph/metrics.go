@@ -0,0 +1,81 @@
+package graph
+
+// Depths returns the depth of each type reachable from the named root type, where the depth of a type is the
+// minimum number of fields which must be selected to reach it from the root. The root itself has depth 0.
+//
+// Only output fields are followed: argument edges are ignored, so input types are not included. Traversing
+// from an interface or union to one of its possible types does not add to the depth, since no field selection
+// is required to do so.
+func (g *Graph) Depths(root string) map[string]int {
+	depths := map[string]int{}
+	if _, ok := g.nodes[root]; !ok {
+		return depths
+	}
+
+	// 0-1 BFS: possible type edges have weight 0, and are pushed onto the front of the queue.
+	depths[root] = 0
+	queue := []string{root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, e := range g.edges[current] {
+			if e.kind == edgeKindArgument {
+				continue
+			}
+
+			weight := 1
+			if e.kind == edgeKindPossibleType {
+				weight = 0
+			}
+
+			candidate := depths[current] + weight
+			if existing, ok := depths[e.dst.Name]; ok && existing <= candidate {
+				continue
+			}
+			depths[e.dst.Name] = candidate
+			if weight == 0 {
+				queue = append([]string{e.dst.Name}, queue...)
+			} else {
+				queue = append(queue, e.dst.Name)
+			}
+		}
+	}
+
+	return depths
+}
+
+// Degree describes the number of distinct other types that a type references (FanOut), and is referenced by
+// (FanIn), via fields, arguments, or possible types.
+type Degree struct {
+	FanIn  int `json:"fanIn"`
+	FanOut int `json:"fanOut"`
+}
+
+// Degrees returns the Degree of every type in the graph, keyed by type name. Self-references are not counted.
+func (g *Graph) Degrees() map[string]Degree {
+	in := map[string]map[string]bool{}
+	out := map[string]map[string]bool{}
+	for name := range g.nodes {
+		in[name] = map[string]bool{}
+		out[name] = map[string]bool{}
+	}
+
+	for src, edges := range g.edges {
+		for _, e := range edges {
+			if e.dst.Name == src {
+				continue
+			}
+			out[src][e.dst.Name] = true
+			in[e.dst.Name][src] = true
+		}
+	}
+
+	result := map[string]Degree{}
+	for name := range g.nodes {
+		result[name] = Degree{
+			FanIn:  len(in[name]),
+			FanOut: len(out[name]),
+		}
+	}
+	return result
+}
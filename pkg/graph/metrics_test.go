@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDepths(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		root     string
+		expected map[string]int
+	}{
+		{
+			name: "from query",
+			root: "Query",
+			expected: map[string]int{
+				"Query":        0,
+				"User":         1,
+				"SearchResult": 1,
+				"Repository":   1,
+				"Organization": 2,
+			},
+		},
+		{
+			name: "from nested type",
+			root: "Organization",
+			expected: map[string]int{
+				"Organization": 0,
+				"User":         1,
+				"SearchResult": 1,
+				"Repository":   1,
+			},
+		},
+		{
+			name:     "unknown root",
+			root:     "Mutation",
+			expected: map[string]int{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := loadSchema(t, cyclesSchema)
+			s.FilterBuiltins()
+			g := MakeGraph(s)
+			assert.Equal(t, tc.expected, g.Depths(tc.root))
+		})
+	}
+}
+
+func TestDegrees(t *testing.T) {
+	s := loadSchema(t, cyclesSchema)
+	s.FilterBuiltins()
+	g := MakeGraph(s)
+
+	assert.Equal(t, map[string]Degree{
+		"Query":        {FanIn: 0, FanOut: 3},
+		"User":         {FanIn: 4, FanOut: 2},
+		"Organization": {FanIn: 1, FanOut: 2},
+		"Repository":   {FanIn: 2, FanOut: 1},
+		"SearchResult": {FanIn: 2, FanOut: 2},
+		"SearchFilter": {FanIn: 1, FanOut: 0},
+	}, g.Degrees())
+}
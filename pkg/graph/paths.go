@@ -0,0 +1,261 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/model"
+)
+
+// PathStep represents a single hop along a Path, via either a field, or an argument of a field.
+type PathStep struct {
+	TypeName     string `json:"typeName"`
+	FieldName    string `json:"fieldName"`
+	ArgumentName string `json:"argumentName,omitempty"`
+}
+
+func (s PathStep) String() string {
+	if s.ArgumentName != "" {
+		return astutil.ArgumentCoordinate(s.TypeName+"."+s.FieldName, s.ArgumentName)
+	}
+	return s.TypeName + "." + s.FieldName
+}
+
+// Path represents a route through the graph, as a sequence of field or argument steps.
+//
+// Hops from a union (or an interface, when using WithInterfacesAsUnions) to one of its possible types
+// are treated as pass-through, and do not appear as steps.
+type Path []PathStep
+
+func (p Path) String() string {
+	var parts []string
+	for _, step := range p {
+		parts = append(parts, step.String())
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// PathOptions controls the behavior of Graph.Paths.
+type PathOptions struct {
+	// MaxLength limits the number of steps in returned paths. Zero means no limit.
+	MaxLength int
+
+	// Limit restricts the result to the given number of shortest paths. Zero means all paths are returned.
+	Limit int
+
+	// ExcludeArguments causes edges from fields to the types of their arguments to be ignored.
+	ExcludeArguments bool
+}
+
+// Paths returns the simple paths (those which visit each type at most once) from the given root to the given
+// target, sorted by length, and then lexically. Both the root and the target may be either a type or a field.
+//
+// If the root is a field, all returned paths begin with a step through that field. If the target is a field,
+// all returned paths end with a step through that field. If the target is a type, returned paths end with a
+// step that leads to that type.
+//
+// The number of simple paths in a large schema can be enormous, so callers should generally set at least one
+// of opts.MaxLength or opts.Limit.
+func (g *Graph) Paths(root, target *model.NameReference, opts PathOptions) []Path {
+	f := &pathFinder{
+		g:      g,
+		target: target,
+		opts:   opts,
+	}
+	f.computeDistances()
+	f.sortEdges()
+
+	maxLength := opts.MaxLength
+	if maxLength <= 0 {
+		// A simple path can't be longer than the number of types in the graph.
+		maxLength = len(g.nodes)
+	}
+
+	// Search for paths of each length in turn. Since edges are visited in lexical order, the paths of each
+	// length are found in sorted order, so we can stop as soon as we have found enough of them.
+	var result []Path
+	for length := 1; length <= maxLength; length++ {
+		remaining := 0
+		if opts.Limit > 0 {
+			remaining = opts.Limit - len(result)
+			if remaining <= 0 {
+				break
+			}
+		}
+		result = append(result, f.search(root, length, remaining)...)
+	}
+
+	return result
+}
+
+type pathFinder struct {
+	g      *Graph
+	target *model.NameReference
+	opts   PathOptions
+
+	// distances holds the minimum number of steps needed to reach the target from each type.
+	distances map[string]int
+
+	// edges holds the usable edges from each type, in the lexical order of the steps they produce.
+	edges map[string][]*edge
+
+	// State for the current search.
+	length int
+	limit  int
+	onPath map[string]bool
+	found  []Path
+}
+
+func (f *pathFinder) usable(e *edge) bool {
+	return !(f.opts.ExcludeArguments && e.kind == edgeKindArgument)
+}
+
+func (f *pathFinder) isTargetField(e *edge) bool {
+	return f.target.FieldName != "" && e.kind == edgeKindField && e.src.Name == f.target.TypeName && e.field.Name == f.target.FieldName
+}
+
+// computeDistances walks backwards from the target in order to find the minimum number of steps needed
+// to reach it from each type. Pass-through edges don't count as steps.
+func (f *pathFinder) computeDistances() {
+	inbound := map[string][]*edge{}
+	for _, name := range astutil.SortedKeys(f.g.edges) {
+		for _, e := range f.g.edges[name] {
+			if f.usable(e) {
+				inbound[e.dst.Name] = append(inbound[e.dst.Name], e)
+			}
+		}
+	}
+
+	f.distances = map[string]int{}
+	if f.target.FieldName != "" {
+		f.distances[f.target.TypeName] = 1
+	} else {
+		f.distances[f.target.TypeName] = 0
+	}
+
+	queue := []string{f.target.TypeName}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, e := range inbound[current] {
+			d := f.distances[current]
+			if e.kind != edgeKindPossibleType {
+				d++
+			}
+			if existing, ok := f.distances[e.src.Name]; ok && existing <= d {
+				continue
+			}
+			f.distances[e.src.Name] = d
+			queue = append(queue, e.src.Name)
+		}
+	}
+}
+
+// sortEdges populates f.edges, so that paths are found in lexical order. Pass-through edges are ordered by the
+// name of the type they lead to, since that type is the source of the next step.
+func (f *pathFinder) sortEdges() {
+	sortKey := func(e *edge) string {
+		switch e.kind {
+		case edgeKindField:
+			return e.field.Name
+		case edgeKindArgument:
+			// Sort arguments after the field itself, but before any other field which it is a prefix of.
+			return e.field.Name + "\x00" + e.argument.Name
+		default:
+			return e.dst.Name
+		}
+	}
+
+	f.edges = map[string][]*edge{}
+	for name, edges := range f.g.edges {
+		var usable []*edge
+		for _, e := range edges {
+			if f.usable(e) {
+				usable = append(usable, e)
+			}
+		}
+		sort.SliceStable(usable, func(i, j int) bool {
+			return sortKey(usable[i]) < sortKey(usable[j])
+		})
+		f.edges[name] = usable
+	}
+}
+
+// search returns the paths from root to the target with exactly the given number of steps, in lexical order.
+// If limit is positive, the search stops after finding that many paths.
+func (f *pathFinder) search(root *model.NameReference, length int, limit int) []Path {
+	f.length = length
+	f.limit = limit
+	f.onPath = map[string]bool{root.TypeName: true}
+	f.found = nil
+
+	if root.FieldName == "" {
+		f.visit(root.TypeName, nil)
+	} else {
+		for _, e := range f.edges[root.TypeName] {
+			if e.kind == edgeKindField && e.field.Name == root.FieldName {
+				f.traverse(e, nil)
+			}
+		}
+	}
+
+	return f.found
+}
+
+func (f *pathFinder) done() bool {
+	return f.limit > 0 && len(f.found) >= f.limit
+}
+
+func (f *pathFinder) record(p Path) {
+	if len(p) != f.length || f.done() {
+		return
+	}
+	f.found = append(f.found, append(Path{}, p...))
+}
+
+func (f *pathFinder) visit(typeName string, path Path) {
+	if f.target.FieldName == "" && typeName == f.target.TypeName && len(path) > 0 {
+		f.record(path)
+		return
+	}
+
+	distance, ok := f.distances[typeName]
+	if !ok || len(path)+distance > f.length {
+		return
+	}
+
+	for _, e := range f.edges[typeName] {
+		if f.done() {
+			return
+		}
+		f.traverse(e, path)
+	}
+}
+
+func (f *pathFinder) traverse(e *edge, path Path) {
+	switch e.kind {
+	case edgeKindField:
+		path = append(path, PathStep{TypeName: e.src.Name, FieldName: e.field.Name})
+	case edgeKindArgument:
+		path = append(path, PathStep{TypeName: e.src.Name, FieldName: e.field.Name, ArgumentName: e.argument.Name})
+	}
+
+	if len(path) > f.length {
+		return
+	}
+
+	if f.isTargetField(e) {
+		f.record(path)
+		return
+	}
+
+	next := e.dst.Name
+	if f.onPath[next] {
+		return
+	}
+
+	f.onPath[next] = true
+	f.visit(next, path)
+	delete(f.onPath, next)
+}
@@ -0,0 +1,186 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaths(t *testing.T) {
+	schema := `type Query {
+		viewer: User
+		repository(owner: OwnerFilter): Repository
+		search: SearchResult
+		node: Node
+	}
+
+	type User implements Node {
+		id: ID!
+		repositories: [Repository!]!
+		friends: [User!]!
+	}
+
+	type Repository implements Node {
+		id: ID!
+		owner: User
+		issues: [Issue!]!
+	}
+
+	type Issue implements Node {
+		id: ID!
+		title: String
+	}
+
+	input OwnerFilter {
+		login: String
+		repository: RepositoryFilter
+	}
+
+	input RepositoryFilter {
+		name: String
+	}
+
+	interface Node {
+		id: ID!
+	}
+
+	union SearchResult = User | Repository`
+
+	for _, tc := range []struct {
+		name               string
+		root               string
+		target             string
+		opts               PathOptions
+		interfacesAsUnions bool
+		expected           []string
+	}{
+		{
+			name:   "field target",
+			root:   "Query",
+			target: "Repository.issues",
+			expected: []string{
+				"Query.repository -> Repository.issues",
+				"Query.search -> Repository.issues",
+				"Query.search -> User.repositories -> Repository.issues",
+				"Query.viewer -> User.repositories -> Repository.issues",
+			},
+		},
+		{
+			name:   "type target",
+			root:   "Query",
+			target: "Issue",
+			expected: []string{
+				"Query.repository -> Repository.issues",
+				"Query.search -> Repository.issues",
+				"Query.search -> User.repositories -> Repository.issues",
+				"Query.viewer -> User.repositories -> Repository.issues",
+			},
+		},
+		{
+			name:   "field root",
+			root:   "Query.viewer",
+			target: "Issue.title",
+			expected: []string{
+				"Query.viewer -> User.repositories -> Repository.issues -> Issue.title",
+			},
+		},
+		{
+			name:   "max length",
+			root:   "Query",
+			target: "Issue",
+			opts:   PathOptions{MaxLength: 2},
+			expected: []string{
+				"Query.repository -> Repository.issues",
+				"Query.search -> Repository.issues",
+			},
+		},
+		{
+			name:   "shortest",
+			root:   "Query",
+			target: "Issue",
+			opts:   PathOptions{Limit: 3},
+			expected: []string{
+				"Query.repository -> Repository.issues",
+				"Query.search -> Repository.issues",
+				"Query.search -> User.repositories -> Repository.issues",
+			},
+		},
+		{
+			name:   "arguments",
+			root:   "Query",
+			target: "RepositoryFilter",
+			expected: []string{
+				"Query.repository(owner:) -> OwnerFilter.repository",
+			},
+		},
+		{
+			name:     "excluding arguments",
+			root:     "Query",
+			target:   "RepositoryFilter",
+			opts:     PathOptions{ExcludeArguments: true},
+			expected: nil,
+		},
+		{
+			name:   "cycles are not followed",
+			root:   "Repository",
+			target: "User.friends",
+			expected: []string{
+				"Repository.owner -> User.friends",
+			},
+		},
+		{
+			name:   "interfaces as objects",
+			root:   "Query.node",
+			target: "ID",
+			expected: []string{
+				"Query.node -> Node.id",
+			},
+		},
+		{
+			name:               "interfaces as unions",
+			root:               "Query.node",
+			target:             "ID",
+			interfacesAsUnions: true,
+			expected: []string{
+				"Query.node -> Issue.id",
+				"Query.node -> Repository.id",
+				"Query.node -> User.id",
+				"Query.node -> Repository.issues -> Issue.id",
+				"Query.node -> Repository.owner -> User.id",
+				"Query.node -> User.repositories -> Repository.id",
+				"Query.node -> User.repositories -> Repository.issues -> Issue.id",
+			},
+		},
+		{
+			name:               "shortest stops partway through a length",
+			root:               "Query.node",
+			target:             "ID",
+			interfacesAsUnions: true,
+			opts:               PathOptions{Limit: 2},
+			expected: []string{
+				"Query.node -> Issue.id",
+				"Query.node -> Repository.id",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := loadSchema(t, schema)
+
+			var opts []GraphOption
+			if tc.interfacesAsUnions {
+				opts = append(opts, WithInterfacesAsUnions())
+			}
+			g := MakeGraph(s, opts...)
+
+			names, err := s.ResolveNames([]string{tc.root, tc.target})
+			assert.NoError(t, err)
+
+			var actual []string
+			for _, p := range g.Paths(names[0], names[1], tc.opts) {
+				actual = append(actual, p.String())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
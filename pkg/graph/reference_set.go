@@ -40,5 +40,6 @@ func (s referenceSet) filterFields(def *model.Definition) *model.Definition {
 		PossibleTypes: def.PossibleTypes,
 		EnumValues:    def.EnumValues,
 		Fields:        filteredFields,
+		Position:      def.Position,
 	}
 }
@@ -0,0 +1,398 @@
+// Package inputs expands the input paths given on the command line into a list of files to read.
+package inputs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// IgnoreFileName is the name of the files used to exclude paths from directory and glob expansion. Ignore
+// files are read from each directory searched during expansion, including the directory being expanded (or
+// the base directory of a glob), but not from its parents. Each line of an ignore file is a pattern,
+// interpreted relative to the directory containing the ignore file.
+// The supported syntax is a subset of that used by .gitignore files:
+//
+//   - Blank lines and lines starting with '#' are skipped.
+//   - A pattern without a '/' (other than a trailing one) matches file and directory names at any depth.
+//   - A pattern containing a '/' is matched against the whole path, relative to the ignore file.
+//   - A pattern with a trailing '/' only matches directories.
+//   - '*', '?', and '[...]' match within a single path component, and '**' matches any number of them.
+//   - A pattern starting with '!' re-includes paths excluded by an earlier pattern, unless a parent
+//     directory is excluded.
+const IgnoreFileName = ".gquilignore"
+
+// Expand replaces directories and glob patterns in the given list of paths with the files they refer to.
+//
+// Directories are searched recursively for files with one of the given extensions. Paths which don't exist,
+// but contain any of the characters '*', '?', or '[', are treated as glob patterns, in which '**' matches any
+// number of path components. Files matched by a glob are included regardless of their extension, while
+// directories matched by a glob are searched as above. Files and directories excluded by an ignore file (see
+// IgnoreFileName) are skipped during both kinds of expansion. Paths naming a file directly are always included.
+//
+// The result is in the order the paths were given, with the files within each directory or glob in lexical
+// order. Files referred to more than once are only included the first time. The path '-' (referring to stdin)
+// is passed through unchanged.
+//
+// An error is returned if a path doesn't exist, or if a directory or glob doesn't match any files.
+func Expand(paths []string, extensions []string) ([]string, error) {
+	e := &expander{
+		extensions: extensions,
+		seen:       map[string]bool{},
+		ignores:    map[string][]ignoreRule{},
+	}
+
+	for _, p := range paths {
+		if p == "-" {
+			e.result = append(e.result, p)
+			continue
+		}
+
+		info, err := os.Stat(p)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && isGlob(p) {
+				if err := e.expandGlob(p); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, fmt.Errorf("could not read %s: %w", p, errors.Unwrap(err))
+		}
+
+		if !info.IsDir() {
+			e.add(p)
+			continue
+		}
+
+		before := e.matched
+		if err := e.expandDir(p); err != nil {
+			return nil, err
+		}
+		if e.matched == before {
+			return nil, fmt.Errorf("no files with extension %s found in directory %s", strings.Join(extensions, ", "), p)
+		}
+	}
+
+	return e.result, nil
+}
+
+type expander struct {
+	extensions []string
+	result     []string
+	seen       map[string]bool
+
+	// matched counts the files matched so far, including those which were already included.
+	matched int
+
+	// ignores holds the rules from the ignore file in each directory visited so far, keyed by directory.
+	ignores map[string][]ignoreRule
+}
+
+func (e *expander) add(p string) {
+	e.matched++
+	p = filepath.Clean(p)
+	if e.seen[p] {
+		return
+	}
+	e.seen[p] = true
+	e.result = append(e.result, p)
+}
+
+func (e *expander) expandDir(root string) error {
+	return e.walk(root, func(p string, d fs.DirEntry) error {
+		if !d.IsDir() && slices.Contains(e.extensions, filepath.Ext(p)) {
+			e.add(p)
+		}
+		return nil
+	})
+}
+
+func (e *expander) expandGlob(pattern string) error {
+	if _, err := path.Match(filepath.ToSlash(pattern), ""); err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+
+	base, rest := splitGlob(pattern)
+	if _, err := os.Stat(base); err != nil {
+		return fmt.Errorf("no files match pattern %s", pattern)
+	}
+
+	before := e.matched
+	err := e.walk(base, func(p string, d fs.DirEntry) error {
+		if p == base {
+			return nil
+		}
+
+		rel, err := filepath.Rel(base, p)
+		if err != nil {
+			return err
+		}
+		segments := strings.Split(filepath.ToSlash(rel), "/")
+
+		// As in most shells, hidden files and directories are only matched by pattern components which also
+		// begin with '.'.
+		if strings.HasPrefix(d.Name(), ".") && !matchesHidden(rest, segments) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if !d.IsDir() {
+			if matchSegments(rest, segments) {
+				e.add(p)
+			}
+			return nil
+		}
+
+		if matchSegments(rest, segments) {
+			if err := e.expandDir(p); err != nil {
+				return err
+			}
+			return fs.SkipDir
+		}
+		if !matchPrefix(rest, segments) {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if e.matched == before {
+		return fmt.Errorf("no files match pattern %s", pattern)
+	}
+	return nil
+}
+
+// walk calls fn for each file and directory beneath root in lexical order, skipping those excluded by ignore
+// files. fn may return fs.SkipDir to avoid descending into a directory.
+func (e *expander) walk(root string, fn func(p string, d fs.DirEntry) error) error {
+	root = filepath.Clean(root)
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", p, err)
+		}
+
+		if p != root && e.ignored(p, d.IsDir()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if err := e.loadIgnoreFile(p); err != nil {
+				return err
+			}
+		}
+
+		return fn(p, d)
+	})
+}
+
+func (e *expander) loadIgnoreFile(dir string) error {
+	if _, ok := e.ignores[dir]; ok {
+		return nil
+	}
+
+	rules, err := readIgnoreFile(filepath.Join(dir, IgnoreFileName))
+	if err != nil {
+		return err
+	}
+	e.ignores[dir] = rules
+	return nil
+}
+
+// ignored returns true if p is excluded by the ignore files loaded from any of its parent directories. Rules
+// from ignore files closer to p take precedence, as do later rules within a single file.
+func (e *expander) ignored(p string, isDir bool) bool {
+	var dirs []string
+	for dir := filepath.Dir(p); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	result := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		rel, err := filepath.Rel(dirs[i], p)
+		if err != nil {
+			continue
+		}
+		segments := strings.Split(filepath.ToSlash(rel), "/")
+		for _, rule := range e.ignores[dirs[i]] {
+			if rule.matches(segments, isDir) {
+				result = !rule.negated
+			}
+		}
+	}
+	return result
+}
+
+type ignoreRule struct {
+	pattern  []string
+	negated  bool
+	dirOnly  bool
+	anchored bool
+}
+
+func (r ignoreRule) matches(segments []string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if r.anchored {
+		return matchSegments(r.pattern, segments)
+	}
+	return matchSegments(r.pattern, segments[len(segments)-1:])
+}
+
+func readIgnoreFile(p string) ([]ignoreRule, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read ignore file %s: %w", p, errors.Unwrap(err))
+	}
+	defer f.Close()
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := parseIgnoreRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", p, lineNumber, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read ignore file %s: %w", p, err)
+	}
+	return rules, nil
+}
+
+func parseIgnoreRule(line string) (ignoreRule, error) {
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negated = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+
+	if line == "" {
+		return rule, fmt.Errorf("empty pattern")
+	}
+	if _, err := path.Match(line, ""); err != nil {
+		return rule, fmt.Errorf("invalid pattern '%s': %w", line, err)
+	}
+
+	rule.pattern = strings.Split(line, "/")
+	return rule, nil
+}
+
+func isGlob(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// splitGlob splits the given glob pattern into a base directory containing no glob characters, and the
+// remaining pattern components.
+func splitGlob(pattern string) (string, []string) {
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	i := 0
+	for i < len(segments)-1 && !isGlob(segments[i]) {
+		i++
+	}
+
+	base := filepath.FromSlash(strings.Join(segments[:i], "/"))
+	if base == "" {
+		base = "."
+		if strings.HasPrefix(pattern, "/") {
+			base = "/"
+		}
+	}
+	return filepath.Clean(base), segments[i:]
+}
+
+// matchSegments returns true if the given path components match the given pattern components, where '**'
+// matches any number of path components.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// matchesHidden returns true if the last of the given path components may be matched by a component of the
+// given pattern which begins with '.'.
+func matchesHidden(pattern, segments []string) bool {
+	i := len(segments) - 1
+	for j, component := range pattern {
+		if component == "**" {
+			// Any later component could correspond to the last path component.
+			for _, later := range pattern[j:] {
+				if strings.HasPrefix(later, ".") {
+					return true
+				}
+			}
+			return false
+		}
+		if j == i {
+			return strings.HasPrefix(component, ".")
+		}
+	}
+	return false
+}
+
+// matchPrefix returns true if the given path components could be the beginning of a path matching the given
+// pattern components.
+func matchPrefix(pattern, segments []string) bool {
+	for len(segments) > 0 {
+		if len(pattern) == 0 {
+			return false
+		}
+		if pattern[0] == "**" {
+			return true
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package inputs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var extensions = []string{".graphql", ".graphqls", ".gql"}
+
+func TestExpand(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		paths    []string
+		expected []string
+		err      string
+	}{
+		{
+			name: "explicit files",
+			files: map[string]string{
+				"b.graphql": "",
+				"a.txt":     "",
+			},
+			paths:    []string{"b.graphql", "a.txt", "-"},
+			expected: []string{"b.graphql", "a.txt", "-"},
+		},
+		{
+			name: "directory",
+			files: map[string]string{
+				"schema/b.graphqls":      "",
+				"schema/a.graphql":       "",
+				"schema/nested/c.gql":    "",
+				"schema/nested/d.json":   "",
+				"schema/notes/README.md": "",
+			},
+			paths:    []string{"schema"},
+			expected: []string{"schema/a.graphql", "schema/b.graphqls", "schema/nested/c.gql"},
+		},
+		{
+			name: "empty directory",
+			files: map[string]string{
+				"schema/README.md": "",
+			},
+			paths: []string{"schema"},
+			err:   "no files with extension .graphql, .graphqls, .gql found in directory schema",
+		},
+		{
+			name:  "missing file",
+			paths: []string{"missing.graphql"},
+			err:   "could not read missing.graphql: no such file or directory",
+		},
+		{
+			name: "glob",
+			files: map[string]string{
+				"a.graphql":          "",
+				"b.graphql":          "",
+				"c.gql":              "",
+				"sub/d.graphql":      "",
+				"schema.json":        "",
+				"services/x/a.gql":   "",
+				"services/y/b.gql":   "",
+				"services/y/c.txt":   "",
+				"services/z/d.proto": "",
+			},
+			paths:    []string{"*.graphql", "*.json", "services/*"},
+			expected: []string{"a.graphql", "b.graphql", "schema.json", "services/x/a.gql", "services/y/b.gql"},
+		},
+		{
+			name: "recursive glob",
+			files: map[string]string{
+				"a.graphql":            "",
+				"sub/b.graphql":        "",
+				"sub/deeper/c.gql":     "",
+				"sub/deeper/d.graphql": "",
+			},
+			paths:    []string{"**/*.graphql"},
+			expected: []string{"a.graphql", "sub/b.graphql", "sub/deeper/d.graphql"},
+		},
+		{
+			name: "hidden files",
+			files: map[string]string{
+				"top.graphql":             "",
+				".hidden.graphql":         "",
+				".hidden/a.graphql":       "",
+				"visible/b.graphql":       "",
+				"visible/.c.graphql":      "",
+				"visible/.hidden/d.gql":   "",
+				"visible/.hidden/e.gql":   "",
+				"visible/nested/f.gql":    "",
+				"visible/nested/.g.gql":   "",
+				"visible/nested/.h/i.gql": "",
+			},
+			paths:    []string{"*.graphql", "**/*.gql", "visible/.hidden/*", ".*.graphql"},
+			expected: []string{"top.graphql", "visible/nested/f.gql", "visible/.hidden/d.gql", "visible/.hidden/e.gql", ".hidden.graphql"},
+		},
+		{
+			name: "unmatched glob",
+			files: map[string]string{
+				"a.graphql": "",
+			},
+			paths: []string{"*.gql"},
+			err:   "no files match pattern *.gql",
+		},
+		{
+			name: "duplicates",
+			files: map[string]string{
+				"schema/a.graphql": "",
+				"schema/b.graphql": "",
+			},
+			paths:    []string{"schema/b.graphql", "schema", "./schema/a.graphql"},
+			expected: []string{"schema/b.graphql", "schema/a.graphql"},
+		},
+		{
+			name: "ignore files",
+			files: map[string]string{
+				".gquilignore":                "# generated code\n*.generated.graphql\nvendor/\n/sub/skipped.graphql\n",
+				"a.graphql":                   "",
+				"a.generated.graphql":         "",
+				"vendor/v.graphql":            "",
+				"sub/skipped.graphql":         "",
+				"sub/kept.graphql":            "",
+				"sub/.gquilignore":            "!keep.generated.graphql\n",
+				"sub/keep.generated.graphql":  "",
+				"sub/other.generated.graphql": "",
+			},
+			paths:    []string{"."},
+			expected: []string{"a.graphql", "sub/keep.generated.graphql", "sub/kept.graphql"},
+		},
+		{
+			name: "ignore files with globs",
+			files: map[string]string{
+				".gquilignore":          "**/internal/**\n",
+				"a/schema.graphql":      "",
+				"a/internal/x.graphql":  "",
+				"b/schema.graphql":      "",
+				"b/internal/y.graphql":  "",
+				"b/internal.graphql":    "",
+				"b/c/internal/z.gql":    "",
+				"b/c/not_internal.gql":  "",
+				"b/c/internal.txt":      "",
+				"b/c/internal/a/b.gql":  "",
+				"b/c/internal/a/c.gql2": "",
+			},
+			paths:    []string{"*"},
+			expected: []string{"a/schema.graphql", "b/c/not_internal.gql", "b/internal.graphql", "b/schema.graphql"},
+		},
+		{
+			name: "explicit files are never ignored",
+			files: map[string]string{
+				".gquilignore":        "*.generated.graphql\n",
+				"a.generated.graphql": "",
+			},
+			paths:    []string{"a.generated.graphql"},
+			expected: []string{"a.generated.graphql"},
+		},
+		{
+			name: "invalid ignore file",
+			files: map[string]string{
+				".gquilignore": "# comment\n[\n",
+				"a.graphql":    "",
+			},
+			paths: []string{"."},
+			err:   ".gquilignore:2: invalid pattern '[': syntax error in pattern",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				p := filepath.Join(dir, filepath.FromSlash(name))
+				assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+				assert.NoError(t, os.WriteFile(p, []byte(content), 0644))
+			}
+			chdir(t, dir)
+
+			actual, err := Expand(tc.paths, extensions)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var expected []string
+			for _, p := range tc.expected {
+				expected = append(expected, filepath.FromSlash(p))
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+	for _, tc := range []struct {
+		pattern  []string
+		segments []string
+		expected bool
+	}{
+		{[]string{"*.graphql"}, []string{"a.graphql"}, true},
+		{[]string{"*.graphql"}, []string{"sub", "a.graphql"}, false},
+		{[]string{"**", "*.graphql"}, []string{"a.graphql"}, true},
+		{[]string{"**", "*.graphql"}, []string{"x", "y", "a.graphql"}, true},
+		{[]string{"a", "**"}, []string{"a"}, true},
+		{[]string{"a", "**", "b"}, []string{"a", "x", "y", "b"}, true},
+		{[]string{"a", "**", "b"}, []string{"a", "x", "y", "c"}, false},
+		{[]string{"[ab]?"}, []string{"bc"}, true},
+	} {
+		assert.Equal(t, tc.expected, matchSegments(tc.pattern, tc.segments), "%v %v", tc.pattern, tc.segments)
+	}
+}
+
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() {
+		assert.NoError(t, os.Chdir(wd))
+	})
+}
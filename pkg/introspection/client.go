@@ -3,12 +3,10 @@ package introspection
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httputil"
-	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
 )
@@ -87,16 +85,8 @@ func (c *Client) fetchSchema() (*Schema, error) {
 		return nil, err
 	}
 
-	if len(rsp.Errors) != 0 {
-		var errs []error
-		for _, err := range rsp.Errors {
-			forPath := ""
-			if len(err.Path) != 0 {
-				forPath = fmt.Sprintf(" at path %s", strings.Join(err.Path, "."))
-			}
-			errs = append(errs, fmt.Errorf("error executing introspection query%s: %s", forPath, err.Message))
-		}
-		return nil, errors.Join(errs...)
+	if err := responseErrors(rsp.Errors); err != nil {
+		return nil, err
 	}
 
 	var parsed IntrospectionQueryResult
@@ -0,0 +1,134 @@
+package introspection
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benweint/gquil/pkg/execution"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+func TestClientFetchSchemaAst(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{
+		Name: "schema",
+		Input: `directive @tag(name: String!) repeatable on FIELD_DEFINITION
+
+		type Query {
+			"Look up a fruit by name."
+			fruit(name: String! = "apple"): Fruit
+			edibles: [Edible!]! @deprecated(reason: "Use fruit instead.")
+		}
+
+		interface Edible {
+			calories: Int
+		}
+
+		type Apple implements Edible {
+			calories: Int
+			variety: AppleVariety
+		}
+
+		type Orange implements Edible {
+			calories: Int
+		}
+
+		union Fruit = Apple | Orange
+
+		enum AppleVariety {
+			FUJI
+			GALA
+		}`,
+	})
+
+	server := httptest.NewServer(execution.NewHandler(schema))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name        string
+		specVersion SpecVersion
+		expected    string
+	}{
+		{
+			name:        "june2018",
+			specVersion: specVersions["june2018"],
+			expected: `directive @tag(name: String!) on FIELD_DEFINITION
+type Apple implements Edible {
+	calories: Int
+	variety: AppleVariety
+}
+enum AppleVariety {
+	FUJI
+	GALA
+}
+interface Edible {
+	calories: Int
+}
+union Fruit = Apple | Orange
+type Orange implements Edible {
+	calories: Int
+}
+type Query {
+	"""
+	Look up a fruit by name.
+	"""
+	fruit(name: String! = "apple"): Fruit
+	edibles: [Edible!]! @deprecated(reason: "Use fruit instead.")
+}
+`,
+		},
+		{
+			name:        "october2021",
+			specVersion: specVersions["october2021"],
+			expected: `directive @tag(name: String!) repeatable on FIELD_DEFINITION
+type Apple implements Edible {
+	calories: Int
+	variety: AppleVariety
+}
+enum AppleVariety {
+	FUJI
+	GALA
+}
+interface Edible {
+	calories: Int
+}
+union Fruit = Apple | Orange
+type Orange implements Edible {
+	calories: Int
+}
+type Query {
+	"""
+	Look up a fruit by name.
+	"""
+	fruit(name: String! = "apple"): Fruit
+	edibles: [Edible!]! @deprecated(reason: "Use fruit instead.")
+}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := NewClient(server.URL, nil, tc.specVersion, nil)
+			s, err := client.FetchSchemaAst()
+			assert.NoError(t, err)
+			removeBuiltins(s)
+
+			var buf bytes.Buffer
+			formatter.NewFormatter(&buf, formatter.WithIndent("\t")).FormatSchema(s)
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+// removeBuiltins removes the types and directives defined in the GraphQL prelude from s.
+func removeBuiltins(s *ast.Schema) {
+	prelude := gqlparser.MustLoadSchema()
+	for name := range prelude.Types {
+		delete(s.Types, name)
+	}
+	for name := range prelude.Directives {
+		delete(s.Directives, name)
+	}
+}
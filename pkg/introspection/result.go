@@ -0,0 +1,64 @@
+package introspection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ParseSchemaAst converts a saved introspection query result into an *ast.Schema.
+//
+// The given JSON may be a complete GraphQL response (with the __schema field nested under a top-level 'data'
+// field), just the contents of the 'data' field, or the bare value of the __schema field.
+func ParseSchemaAst(raw []byte) (*ast.Schema, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("failed to deserialize introspection result: %w", err)
+	}
+
+	_, hasData := fields["data"]
+	_, hasErrors := fields["errors"]
+	if hasData || hasErrors {
+		var rsp graphQLResponse
+		if err := json.Unmarshal(raw, &rsp); err != nil {
+			return nil, fmt.Errorf("failed to deserialize introspection response: %w", err)
+		}
+		if err := responseErrors(rsp.Errors); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(rsp.Data, &fields); err != nil {
+			return nil, fmt.Errorf("failed to deserialize introspection response data: %w", err)
+		}
+	}
+
+	schemaJSON, ok := fields["__schema"]
+	if !ok {
+		if _, hasTypes := fields["types"]; !hasTypes {
+			return nil, errors.New("unrecognized introspection result format: expected a '__schema' or 'types' field")
+		}
+		schemaJSON = raw
+	}
+
+	var s Schema
+	if err := json.Unmarshal(schemaJSON, &s); err != nil {
+		return nil, fmt.Errorf("failed to deserialize introspection result: %w", err)
+	}
+
+	return responseToAst(&s)
+}
+
+// responseErrors returns an error describing the given GraphQL response errors, or nil if there are none.
+func responseErrors(errs []graphQLError) error {
+	var result []error
+	for _, err := range errs {
+		forPath := ""
+		if len(err.Path) != 0 {
+			forPath = fmt.Sprintf(" at path %s", strings.Join(err.Path, "."))
+		}
+		result = append(result, fmt.Errorf("error executing introspection query%s: %s", forPath, err.Message))
+	}
+	return errors.Join(result...)
+}
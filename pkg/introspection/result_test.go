@@ -0,0 +1,81 @@
+package introspection
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+func TestParseSchemaAst(t *testing.T) {
+	schema := `{
+		"queryType": {"name": "Query"},
+		"types": [
+			{
+				"kind": "OBJECT",
+				"name": "Query",
+				"fields": [
+					{"name": "hello", "args": [], "type": {"kind": "SCALAR", "name": "String"}}
+				],
+				"interfaces": []
+			},
+			{"kind": "SCALAR", "name": "String"}
+		],
+		"directives": []
+	}`
+
+	expectedSDL := `type Query {
+	hello: String
+}
+scalar String
+`
+
+	for _, tc := range []struct {
+		name        string
+		input       string
+		expectedErr string
+	}{
+		{
+			name:  "full response",
+			input: `{"data": {"__schema": ` + schema + `}}`,
+		},
+		{
+			name:  "response data only",
+			input: `{"__schema": ` + schema + `}`,
+		},
+		{
+			name:  "bare schema",
+			input: schema,
+		},
+		{
+			name:        "response with errors",
+			input:       `{"data": null, "errors": [{"message": "introspection is disabled", "path": ["__schema"]}]}`,
+			expectedErr: "error executing introspection query at path __schema: introspection is disabled",
+		},
+		{
+			name:        "unrecognized format",
+			input:       `{"schema": {}}`,
+			expectedErr: "unrecognized introspection result format: expected a '__schema' or 'types' field",
+		},
+		{
+			name:        "invalid JSON",
+			input:       `{"data":`,
+			expectedErr: "failed to deserialize introspection result: unexpected end of JSON input",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := ParseSchemaAst([]byte(tc.input))
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			var buf bytes.Buffer
+			f := formatter.NewFormatter(&buf)
+			f.FormatSchema(s)
+			assert.Equal(t, expectedSDL, buf.String())
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+	"gopkg.in/yaml.v2"
+)
+
+// Severity describes how serious a lint violation is.
+type Severity string
+
+const (
+	SeverityError   = Severity("error")
+	SeverityWarning = Severity("warning")
+)
+
+// Options holds rule-specific configuration values, as read from the 'options' key of a rule's config.
+type Options map[string]any
+
+// String returns the string-valued option with the given key, or def if it is unset.
+func (o Options) String(key, def string) string {
+	if raw, ok := o[key]; ok {
+		if s, ok := raw.(string); ok {
+			return s
+		}
+	}
+	return def
+}
+
+// Rule is a single named check which can be run against a schema.
+//
+// Rules report violations via the given Reporter, and should not modify the schema.
+type Rule struct {
+	Name            string
+	Description     string
+	DefaultSeverity Severity
+	Check           func(s *model.Schema, opts Options, r *Reporter)
+}
+
+// Violation represents a single reported lint problem.
+type Violation struct {
+	Rule       string   `json:"rule"`
+	Severity   Severity `json:"severity"`
+	Coordinate string   `json:"coordinate"`
+	Message    string   `json:"message"`
+	File       string   `json:"file,omitempty"`
+	Line       int      `json:"line,omitempty"`
+	Column     int      `json:"column,omitempty"`
+}
+
+func (v *Violation) String() string {
+	location := v.Coordinate
+	if v.File != "" {
+		location = fmt.Sprintf("%s:%d:%d: %s", v.File, v.Line, v.Column, v.Coordinate)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", location, v.Severity, v.Message, v.Rule)
+}
+
+// ViolationList is a list of lint violations, typically across many rules.
+type ViolationList []*Violation
+
+// Sort orders violations by source location, then coordinate, then rule name.
+func (vl ViolationList) Sort() {
+	sort.SliceStable(vl, func(i, j int) bool {
+		a, b := vl[i], vl[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		if a.Coordinate != b.Coordinate {
+			return a.Coordinate < b.Coordinate
+		}
+		return a.Rule < b.Rule
+	})
+}
+
+// Count returns the number of violations with the given severity.
+func (vl ViolationList) Count(severity Severity) int {
+	n := 0
+	for _, v := range vl {
+		if v.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// Reporter collects violations on behalf of a single rule.
+type Reporter struct {
+	rule       *Rule
+	severity   Severity
+	violations ViolationList
+}
+
+// Report records a violation for the schema element identified by coordinate, located at pos.
+// pos may be nil if the source location is unknown.
+func (r *Reporter) Report(pos *ast.Position, coordinate string, msg string, args ...any) {
+	v := &Violation{
+		Rule:       r.rule.Name,
+		Severity:   r.severity,
+		Coordinate: coordinate,
+		Message:    fmt.Sprintf(msg, args...),
+	}
+	if pos != nil {
+		if pos.Src != nil {
+			v.File = pos.Src.Name
+		}
+		v.Line = pos.Line
+		v.Column = pos.Column
+	}
+	r.violations = append(r.violations, v)
+}
+
+// Config controls which rules are run, and how. It is typically loaded from a YAML file like this:
+//
+//	rules:
+//	  type-descriptions:
+//	    enabled: false
+//	  input-type-suffix:
+//	    severity: warning
+//	    options:
+//	      suffix: Input
+type Config struct {
+	Rules map[string]RuleConfig `yaml:"rules"`
+}
+
+// RuleConfig holds the configuration for a single rule. Unset fields fall back to the rule's defaults.
+type RuleConfig struct {
+	Enabled  *bool    `yaml:"enabled"`
+	Severity Severity `yaml:"severity"`
+	Options  Options  `yaml:"options"`
+}
+
+// LoadConfig reads a Config from the YAML file at the given path.
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("could not read lint config from %s: %w", path, err)
+	}
+
+	if err := yaml.UnmarshalStrict(raw, &config); err != nil {
+		return config, fmt.Errorf("failed to parse lint config from %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// SetEnabled overrides the enabled state for the named rule.
+func (c *Config) SetEnabled(name string, enabled bool) {
+	if c.Rules == nil {
+		c.Rules = map[string]RuleConfig{}
+	}
+	rc := c.Rules[name]
+	rc.Enabled = &enabled
+	c.Rules[name] = rc
+}
+
+// Linter runs a set of rules against schemas.
+type Linter struct {
+	rules  []*Rule
+	config Config
+}
+
+// NewLinter returns a Linter which will run the given rules according to the given config.
+// An error is returned if the config refers to unknown rules or contains invalid severities.
+func NewLinter(rules []*Rule, config Config) (*Linter, error) {
+	var known []string
+	for _, rule := range rules {
+		known = append(known, rule.Name)
+	}
+
+	var unknown []string
+	for name, rc := range config.Rules {
+		if !slices.Contains(known, name) {
+			unknown = append(unknown, name)
+		}
+		if rc.Severity != "" && rc.Severity != SeverityError && rc.Severity != SeverityWarning {
+			return nil, fmt.Errorf("invalid severity '%s' for rule %s, expected one of %s, %s", rc.Severity, name, SeverityError, SeverityWarning)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown lint rule(s): %s", strings.Join(unknown, ", "))
+	}
+
+	return &Linter{
+		rules:  rules,
+		config: config,
+	}, nil
+}
+
+// Rules returns the rules which will be run by this linter, taking the config into account.
+func (l *Linter) Rules() []*Rule {
+	var result []*Rule
+	for _, rule := range l.rules {
+		rc := l.config.Rules[rule.Name]
+		if rc.Enabled != nil && !*rc.Enabled {
+			continue
+		}
+		result = append(result, rule)
+	}
+	return result
+}
+
+// Run runs all enabled rules against the given schema, and returns the sorted list of violations.
+func (l *Linter) Run(s *model.Schema) ViolationList {
+	var result ViolationList
+	for _, rule := range l.Rules() {
+		rc := l.config.Rules[rule.Name]
+		severity := rule.DefaultSeverity
+		if rc.Severity != "" {
+			severity = rc.Severity
+		}
+
+		r := &Reporter{
+			rule:     rule,
+			severity: severity,
+		}
+		rule.Check(s, rc.Options, r)
+		result = append(result, r.violations...)
+	}
+	result.Sort()
+	return result
+}
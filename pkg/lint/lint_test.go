@@ -0,0 +1,124 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testSchema = `type Query {
+	user(user_id: ID!): user
+}
+
+type user {
+	Name: String
+	status: Status @deprecated
+}
+
+input Filter {
+	nameLike: String
+}
+
+enum Status {
+	active
+	INACTIVE
+}`
+
+func TestLinter(t *testing.T) {
+	disabled := false
+	for _, tc := range []struct {
+		name      string
+		config    Config
+		wantError bool
+		expected  []string
+	}{
+		{
+			name: "naming rules",
+			config: Config{
+				Rules: map[string]RuleConfig{
+					"type-descriptions":  {Enabled: &disabled},
+					"field-descriptions": {Enabled: &disabled},
+				},
+			},
+			expected: []string{
+				"testcase:2:7: Query.user(user_id:): error: argument name 'user_id' should be camelCase [argument-names-camel-case]",
+				"testcase:5:6: user: error: type name 'user' should be PascalCase [type-names-pascal-case]",
+				"testcase:6:2: user.Name: error: field name 'Name' should be camelCase [field-names-camel-case]",
+				"testcase:7:2: user.status: warning: 'user.status' is deprecated without a reason [deprecated-without-reason]",
+				"testcase:10:7: Filter: error: input object type name 'Filter' should end with 'Input' [input-type-suffix]",
+				"testcase:15:2: Status.active: error: enum value 'active' should be SCREAMING_SNAKE_CASE [enum-values-screaming-case]",
+			},
+		},
+		{
+			name: "severity and options",
+			config: Config{
+				Rules: map[string]RuleConfig{
+					"type-descriptions":          {Enabled: &disabled},
+					"field-descriptions":         {Enabled: &disabled},
+					"argument-names-camel-case":  {Enabled: &disabled},
+					"type-names-pascal-case":     {Enabled: &disabled},
+					"field-names-camel-case":     {Enabled: &disabled},
+					"enum-values-screaming-case": {Enabled: &disabled},
+					"deprecated-without-reason":  {Severity: SeverityError},
+					"input-type-suffix": {
+						Options: Options{"suffix": "Filter"},
+					},
+				},
+			},
+			expected: []string{
+				"testcase:7:2: user.status: error: 'user.status' is deprecated without a reason [deprecated-without-reason]",
+			},
+		},
+		{
+			name: "unknown rule",
+			config: Config{
+				Rules: map[string]RuleConfig{
+					"not-a-rule": {},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid severity",
+			config: Config{
+				Rules: map[string]RuleConfig{
+					"type-descriptions": {Severity: "fatal"},
+				},
+			},
+			wantError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			linter, err := NewLinter(DefaultRules(), tc.config)
+			if tc.wantError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var actual []string
+			for _, v := range linter.Run(loadSchema(t, testSchema)) {
+				actual = append(actual, v.String())
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func loadSchema(t *testing.T, raw string) *model.Schema {
+	src := ast.Source{
+		Name:  "testcase",
+		Input: raw,
+	}
+	rawSchema, err := gqlparser.LoadSchema(&src)
+	assert.NoError(t, err)
+
+	s, err := model.MakeSchema(rawSchema)
+	assert.NoError(t, err)
+
+	s.FilterBuiltins()
+	return s
+}
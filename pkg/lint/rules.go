@@ -0,0 +1,168 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var (
+	pascalCasePattern    = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	camelCasePattern     = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	screamingCasePattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+// DefaultRules returns the set of built-in lint rules, sorted by name.
+func DefaultRules() []*Rule {
+	return []*Rule{
+		{
+			Name:            "argument-names-camel-case",
+			Description:     "Field and directive argument names should be camelCase.",
+			DefaultSeverity: SeverityError,
+			Check:           checkArgumentNames,
+		},
+		{
+			Name:            "deprecated-without-reason",
+			Description:     "Uses of @deprecated should include a non-empty reason, rather than relying on the default.",
+			DefaultSeverity: SeverityWarning,
+			Check:           checkDeprecationReasons,
+		},
+		{
+			Name:            "enum-values-screaming-case",
+			Description:     "Enum values should be SCREAMING_SNAKE_CASE.",
+			DefaultSeverity: SeverityError,
+			Check:           checkEnumValueNames,
+		},
+		{
+			Name:            "field-descriptions",
+			Description:     "Fields and input fields should have descriptions.",
+			DefaultSeverity: SeverityWarning,
+			Check:           checkFieldDescriptions,
+		},
+		{
+			Name:            "field-names-camel-case",
+			Description:     "Field and input field names should be camelCase.",
+			DefaultSeverity: SeverityError,
+			Check:           checkFieldNames,
+		},
+		{
+			Name:            "input-type-suffix",
+			Description:     "Input object type names should end with a common suffix. Options: suffix (default: Input).",
+			DefaultSeverity: SeverityError,
+			Check:           checkInputTypeSuffix,
+		},
+		{
+			Name:            "type-descriptions",
+			Description:     "Types should have descriptions.",
+			DefaultSeverity: SeverityWarning,
+			Check:           checkTypeDescriptions,
+		},
+		{
+			Name:            "type-names-pascal-case",
+			Description:     "Type names should be PascalCase.",
+			DefaultSeverity: SeverityError,
+			Check:           checkTypeNames,
+		},
+	}
+}
+
+func checkTypeNames(s *model.Schema, _ Options, r *Reporter) {
+	for _, t := range s.Types.ToSortedList() {
+		if !pascalCasePattern.MatchString(t.Name) {
+			r.Report(t.Position, t.Name, "type name '%s' should be PascalCase", t.Name)
+		}
+	}
+}
+
+func checkFieldNames(s *model.Schema, _ Options, r *Reporter) {
+	for _, t := range s.Types.ToSortedList() {
+		for _, f := range t.Fields {
+			if !camelCasePattern.MatchString(f.Name) {
+				r.Report(f.Position, t.Name+"."+f.Name, "field name '%s' should be camelCase", f.Name)
+			}
+		}
+	}
+}
+
+func checkArgumentNames(s *model.Schema, _ Options, r *Reporter) {
+	check := func(parent string, args model.ArgumentDefinitionList) {
+		for _, arg := range args {
+			if !camelCasePattern.MatchString(arg.Name) {
+				r.Report(arg.Position, astutil.ArgumentCoordinate(parent, arg.Name), "argument name '%s' should be camelCase", arg.Name)
+			}
+		}
+	}
+
+	for _, t := range s.Types.ToSortedList() {
+		for _, f := range t.Fields {
+			check(t.Name+"."+f.Name, f.Arguments)
+		}
+	}
+
+	for _, d := range s.Directives {
+		check("@"+d.Name, d.Arguments)
+	}
+}
+
+func checkEnumValueNames(s *model.Schema, _ Options, r *Reporter) {
+	for _, t := range s.Types.ToSortedList() {
+		for _, ev := range t.EnumValues {
+			if !screamingCasePattern.MatchString(ev.Name) {
+				r.Report(ev.Position, t.Name+"."+ev.Name, "enum value '%s' should be SCREAMING_SNAKE_CASE", ev.Name)
+			}
+		}
+	}
+}
+
+func checkInputTypeSuffix(s *model.Schema, opts Options, r *Reporter) {
+	suffix := opts.String("suffix", "Input")
+	for _, t := range s.Types.ToSortedList() {
+		if t.Kind == ast.InputObject && !strings.HasSuffix(t.Name, suffix) {
+			r.Report(t.Position, t.Name, "input object type name '%s' should end with '%s'", t.Name, suffix)
+		}
+	}
+}
+
+func checkTypeDescriptions(s *model.Schema, _ Options, r *Reporter) {
+	for _, t := range s.Types.ToSortedList() {
+		if strings.TrimSpace(t.Description) == "" {
+			r.Report(t.Position, t.Name, "%s '%s' is missing a description", strings.ToLower(string(t.Kind)), t.Name)
+		}
+	}
+}
+
+func checkFieldDescriptions(s *model.Schema, _ Options, r *Reporter) {
+	for _, t := range s.Types.ToSortedList() {
+		for _, f := range t.Fields {
+			if strings.TrimSpace(f.Description) == "" {
+				r.Report(f.Position, t.Name+"."+f.Name, "field '%s' is missing a description", f.Name)
+			}
+		}
+	}
+}
+
+func checkDeprecationReasons(s *model.Schema, _ Options, r *Reporter) {
+	check := func(pos *ast.Position, coordinate string, directives model.DirectiveList) {
+		reason, ok := directives.DeprecationReason()
+		if !ok || (strings.TrimSpace(reason) != "" && reason != model.DefaultDeprecationReason) {
+			return
+		}
+		r.Report(pos, coordinate, "'%s' is deprecated without a reason", coordinate)
+	}
+
+	for _, t := range s.Types.ToSortedList() {
+		for _, f := range t.Fields {
+			fieldCoordinate := t.Name + "." + f.Name
+			check(f.Position, fieldCoordinate, f.Directives)
+			for _, arg := range f.Arguments {
+				check(arg.Position, astutil.ArgumentCoordinate(fieldCoordinate, arg.Name), arg.Directives)
+			}
+		}
+		for _, ev := range t.EnumValues {
+			check(ev.Position, t.Name+"."+ev.Name, ev.Directives)
+		}
+	}
+}
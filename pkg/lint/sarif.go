@@ -0,0 +1,115 @@
+package lint
+
+// The types in this file represent the subset of the SARIF 2.1.0 format needed to report lint results.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     Severity        `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// ToSARIF returns a value which serializes to a SARIF log describing the given violations, suitable for
+// uploading to code scanning tools. The given rules are listed as the rules supported by the tool.
+func ToSARIF(rules []*Rule, violations ViolationList) any {
+	driver := sarifDriver{
+		Name:           "gquil",
+		InformationURI: "https://github.com/benweint/gquil",
+		Rules:          []sarifRule{},
+	}
+	for _, rule := range rules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:               rule.Name,
+			ShortDescription: sarifMessage{Text: rule.Description},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, v := range violations {
+		location := sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{
+				{FullyQualifiedName: v.Coordinate},
+			},
+		}
+		if v.File != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: v.File},
+				Region: sarifRegion{
+					StartLine:   v.Line,
+					StartColumn: v.Column,
+				},
+			}
+		}
+		results = append(results, sarifResult{
+			RuleID:    v.Rule,
+			Level:     v.Severity,
+			Message:   sarifMessage{Text: v.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	return sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: driver},
+				Results: results,
+			},
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package mock
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Generator produces a mock value for a scalar type, using the given source of randomness.
+type Generator func(rng *rand.Rand) any
+
+// Generators are the named generators which may be assigned to scalar types.
+var Generators = map[string]Generator{
+	"string":   generateString,
+	"int":      generateInt,
+	"float":    generateFloat,
+	"boolean":  generateBoolean,
+	"id":       generateID,
+	"uuid":     generateUUID,
+	"datetime": generateDateTime,
+	"date":     generateDate,
+	"email":    generateEmail,
+	"url":      generateURL,
+}
+
+// GeneratorNames returns the names of all available generators, sorted.
+func GeneratorNames() []string {
+	var names []string
+	for name := range Generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// defaultGenerators maps the built-in scalar types to their generators. Custom scalars not otherwise
+// configured use the string generator.
+var defaultGenerators = map[string]string{
+	"String":  "string",
+	"Int":     "int",
+	"Float":   "float",
+	"Boolean": "boolean",
+	"ID":      "id",
+}
+
+var words = []string{
+	"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel",
+	"india", "juliet", "kilo", "lima", "mike", "november", "oscar", "papa",
+}
+
+func word(rng *rand.Rand) string {
+	return words[rng.Intn(len(words))]
+}
+
+func generateString(rng *rand.Rand) any {
+	return word(rng) + " " + word(rng)
+}
+
+func generateInt(rng *rand.Rand) any {
+	return rng.Intn(1000)
+}
+
+func generateFloat(rng *rand.Rand) any {
+	return float64(rng.Intn(100000)) / 100
+}
+
+func generateBoolean(rng *rand.Rand) any {
+	return rng.Intn(2) == 0
+}
+
+func generateID(rng *rand.Rand) any {
+	return fmt.Sprintf("%08x", rng.Uint32())
+}
+
+func generateUUID(rng *rand.Rand) any {
+	var b [16]byte
+	rng.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// baseTime is the earliest time produced by the datetime and date generators.
+var baseTime = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func randomTime(rng *rand.Rand) time.Time {
+	const fiveYears = 5 * 365 * 24 * 60 * 60
+	return baseTime.Add(time.Duration(rng.Int63n(fiveYears)) * time.Second)
+}
+
+func generateDateTime(rng *rand.Rand) any {
+	return randomTime(rng).Format(time.RFC3339)
+}
+
+func generateDate(rng *rand.Rand) any {
+	return randomTime(rng).Format(time.DateOnly)
+}
+
+func generateEmail(rng *rand.Rand) any {
+	return word(rng) + "." + word(rng) + "@example.com"
+}
+
+func generateURL(rng *rand.Rand) any {
+	return "https://example.com/" + strings.Join([]string{word(rng), word(rng)}, "/")
+}
@@ -0,0 +1,142 @@
+// Package mock generates deterministic mock data for GraphQL schemas, for use with the execution package.
+package mock
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/benweint/gquil/pkg/execution"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Options control the mock data produced by a Resolver.
+type Options struct {
+	// Seed determines the values generated. The same seed always produces the same response for the same
+	// query.
+	Seed int64
+
+	// ListLength is the number of items returned for list fields.
+	ListLength int
+
+	// ScalarGenerators maps scalar type names to the names of the generators (see Generators) used to
+	// produce their values. This may be used to override the defaults for built-in scalars, too.
+	ScalarGenerators map[string]string
+}
+
+// Resolver is an execution.Resolver which returns mock data for every field. Values are derived from
+// the seed and the path of each field in the response, so a given field always has the same value,
+// regardless of what else is selected alongside it.
+type Resolver struct {
+	schema     *ast.Schema
+	seed       int64
+	listLength int
+	generators map[string]Generator
+}
+
+var _ execution.Resolver = (*Resolver)(nil)
+
+// object is the mock value of an object type.
+type object struct {
+	typeName string
+}
+
+// NewResolver returns a new Resolver for the given schema, returning an error if any of the configured scalar
+// generators are unknown, or are configured for types which aren't scalars in the schema.
+func NewResolver(schema *ast.Schema, opts Options) (*Resolver, error) {
+	if opts.ListLength < 0 {
+		return nil, fmt.Errorf("list length must not be negative, got %d", opts.ListLength)
+	}
+
+	generators := map[string]Generator{}
+	for scalarName, generatorName := range defaultGenerators {
+		generators[scalarName] = Generators[generatorName]
+	}
+	for _, scalarName := range astutil.SortedKeys(opts.ScalarGenerators) {
+		generatorName := opts.ScalarGenerators[scalarName]
+		if def := schema.Types[scalarName]; def == nil || def.Kind != ast.Scalar {
+			return nil, fmt.Errorf("cannot use generator '%s' for '%s', which is not a scalar type in the schema", generatorName, scalarName)
+		}
+		generator, ok := Generators[generatorName]
+		if !ok {
+			return nil, fmt.Errorf("unknown generator '%s' for scalar '%s', valid generators are: %s", generatorName, scalarName, strings.Join(GeneratorNames(), ", "))
+		}
+		generators[scalarName] = generator
+	}
+
+	return &Resolver{
+		schema:     schema,
+		seed:       opts.Seed,
+		listLength: opts.ListLength,
+		generators: generators,
+	}, nil
+}
+
+func (r *Resolver) ResolveField(_ any, info execution.FieldInfo) (any, error) {
+	return r.generate(info.Field.Type, r.rngForPath(info.Path))
+}
+
+func (r *Resolver) ResolveType(abstractType *ast.Definition, value any) (string, error) {
+	obj, ok := value.(*object)
+	if !ok {
+		return "", fmt.Errorf("unexpected value of type %T for abstract type '%s'", value, abstractType.Name)
+	}
+	return obj.typeName, nil
+}
+
+// rngForPath returns a source of randomness seeded from both the resolver's seed and the given response path.
+func (r *Resolver) rngForPath(path ast.Path) *rand.Rand {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d:%s", r.seed, path.String())
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+func (r *Resolver) generate(t *ast.Type, rng *rand.Rand) (any, error) {
+	if t.Elem != nil {
+		result := make([]any, r.listLength)
+		for i := range result {
+			item, err := r.generate(t.Elem, rng)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = item
+		}
+		return result, nil
+	}
+
+	def := r.schema.Types[t.NamedType]
+	if def == nil {
+		return nil, fmt.Errorf("unknown type '%s'", t.NamedType)
+	}
+
+	switch def.Kind {
+	case ast.Scalar:
+		generator, ok := r.generators[def.Name]
+		if !ok {
+			generator = generateString
+		}
+		return generator(rng), nil
+	case ast.Enum:
+		if len(def.EnumValues) == 0 {
+			return nil, fmt.Errorf("enum '%s' has no values", def.Name)
+		}
+		return def.EnumValues[rng.Intn(len(def.EnumValues))].Name, nil
+	case ast.Object:
+		return &object{typeName: def.Name}, nil
+	case ast.Interface, ast.Union:
+		var names []string
+		for _, possibleType := range r.schema.GetPossibleTypes(def) {
+			names = append(names, possibleType.Name)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("abstract type '%s' has no possible types", def.Name)
+		}
+		sort.Strings(names)
+		return &object{typeName: names[rng.Intn(len(names))]}, nil
+	default:
+		return nil, fmt.Errorf("cannot generate a value of type '%s'", def.Name)
+	}
+}
@@ -0,0 +1,146 @@
+package mock
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/benweint/gquil/pkg/execution"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testSchema = `
+scalar DateTime
+scalar UUID
+
+type Query {
+	fruits(first: Int): [Fruit!]!
+	edible: Edible
+	basket: Basket!
+}
+
+type Basket {
+	id: ID!
+	createdAt: DateTime!
+	label: String
+}
+
+interface Edible {
+	calories: Int!
+}
+
+type Apple implements Edible {
+	calories: Int!
+	variety: AppleVariety!
+}
+
+type Orange implements Edible {
+	calories: Int!
+	id: UUID!
+}
+
+union Fruit = Apple | Orange
+
+enum AppleVariety {
+	FUJI
+	GALA
+}`
+
+func execute(t *testing.T, opts Options, query string) map[string]any {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+	resolver, err := NewResolver(schema, opts)
+	assert.NoError(t, err)
+
+	rsp := execution.Execute(schema, execution.Request{Query: query}, execution.WithResolver(resolver))
+	assert.Empty(t, rsp.Errors)
+
+	raw, err := json.Marshal(rsp.Data)
+	assert.NoError(t, err)
+
+	var data map[string]any
+	assert.NoError(t, json.Unmarshal(raw, &data))
+	return data
+}
+
+func TestResolver(t *testing.T) {
+	query := `{
+		fruits {
+			__typename
+			... on Apple { calories variety }
+			... on Orange { calories id }
+		}
+		edible { __typename calories }
+		basket { id createdAt label }
+	}`
+
+	t.Run("deterministic", func(t *testing.T) {
+		first := execute(t, Options{Seed: 1, ListLength: 3}, query)
+		second := execute(t, Options{Seed: 1, ListLength: 3}, query)
+		assert.Equal(t, first, second)
+
+		other := execute(t, Options{Seed: 2, ListLength: 3}, query)
+		assert.NotEqual(t, first, other)
+	})
+
+	t.Run("independent of sibling selections", func(t *testing.T) {
+		full := execute(t, Options{Seed: 1, ListLength: 3}, query)
+		partial := execute(t, Options{Seed: 1, ListLength: 3}, `{ basket { id createdAt label } }`)
+		assert.Equal(t, full["basket"], partial["basket"])
+	})
+
+	t.Run("list length", func(t *testing.T) {
+		for _, n := range []int{0, 1, 5} {
+			data := execute(t, Options{ListLength: n}, `{ fruits { __typename } }`)
+			assert.Len(t, data["fruits"], n)
+		}
+	})
+
+	t.Run("values match their types", func(t *testing.T) {
+		data := execute(t, Options{
+			ListLength:       20,
+			ScalarGenerators: map[string]string{"DateTime": "datetime", "UUID": "uuid"},
+		}, query)
+
+		for _, item := range data["fruits"].([]any) {
+			fruit := item.(map[string]any)
+			assert.IsType(t, float64(0), fruit["calories"])
+			switch fruit["__typename"] {
+			case "Apple":
+				assert.Contains(t, []any{"FUJI", "GALA"}, fruit["variety"])
+			case "Orange":
+				assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), fruit["id"])
+			default:
+				t.Errorf("unexpected type %v", fruit["__typename"])
+			}
+		}
+
+		edible := data["edible"].(map[string]any)
+		assert.Contains(t, []any{"Apple", "Orange"}, edible["__typename"])
+
+		basket := data["basket"].(map[string]any)
+		assert.IsType(t, "", basket["id"])
+		assert.IsType(t, "", basket["label"])
+		assert.Regexp(t, regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`), basket["createdAt"])
+	})
+
+	t.Run("custom scalars default to strings", func(t *testing.T) {
+		data := execute(t, Options{}, `{ basket { createdAt } }`)
+		assert.IsType(t, "", data["basket"].(map[string]any)["createdAt"])
+	})
+}
+
+func TestNewResolverUnknownGenerator(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+	_, err := NewResolver(schema, Options{ScalarGenerators: map[string]string{"DateTime": "banana"}})
+	assert.ErrorContains(t, err, "unknown generator 'banana' for scalar 'DateTime'")
+}
+
+func TestNewResolverUnknownScalar(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+	for _, name := range []string{"Timestamp", "Basket"} {
+		_, err := NewResolver(schema, Options{ScalarGenerators: map[string]string{name: "datetime"}})
+		assert.EqualError(t, err, "cannot use generator 'datetime' for '"+name+"', which is not a scalar type in the schema")
+	}
+}
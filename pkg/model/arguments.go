@@ -2,6 +2,9 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
 )
@@ -13,10 +16,26 @@ type ArgumentDefinition struct {
 	DefaultValue Value
 	Type         *Type
 	Directives   DirectiveList
+	Position     *ast.Position
 }
 
 type ArgumentDefinitionList []*ArgumentDefinition
 
+func (adl ArgumentDefinitionList) Sort() {
+	slices.SortFunc(adl, func(a, b *ArgumentDefinition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+func (adl ArgumentDefinitionList) Named(name string) *ArgumentDefinition {
+	for _, arg := range adl {
+		if arg.Name == name {
+			return arg
+		}
+	}
+	return nil
+}
+
 type ArgumentList []*Argument
 
 type Argument struct {
@@ -24,6 +43,24 @@ type Argument struct {
 	Value Value  `json:"value"`
 }
 
+// Format returns the given arguments in parentheses, as they would be written at a directive application site,
+// with values formatted by FormatValue. It returns the empty string if there are no arguments.
+func (al ArgumentList) Format() (string, error) {
+	if len(al) == 0 {
+		return "", nil
+	}
+
+	var formattedArgs []string
+	for _, arg := range al {
+		formattedValue, err := FormatValue(arg.Value)
+		if err != nil {
+			return "", err
+		}
+		formattedArgs = append(formattedArgs, fmt.Sprintf("%s: %s", arg.Name, formattedValue))
+	}
+	return "(" + strings.Join(formattedArgs, ", ") + ")", nil
+}
+
 func (a *ArgumentDefinition) MarshalJSON() ([]byte, error) {
 	m := map[string]any{
 		"name":               a.Name,
@@ -54,6 +91,7 @@ func makeArgumentDefinitionList(in ast.ArgumentDefinitionList) (ArgumentDefiniti
 		if err != nil {
 			return nil, err
 		}
+		argDef.Position = a.Position
 		result = append(result, argDef)
 	}
 	return result, nil
@@ -64,7 +102,7 @@ func makeArgumentDefinition(name, description string, inType *ast.Type, inDirect
 	if err != nil {
 		return nil, err
 	}
-	directives, err := makeDirectiveList(inDirectives)
+	directives, err := MakeDirectiveList(inDirectives)
 	if err != nil {
 		return nil, err
 	}
@@ -27,6 +27,9 @@ type Definition struct {
 
 	// only set for enums
 	EnumValues EnumValueList
+
+	// Position is the location of this definition in the source SDL, if known.
+	Position *ast.Position
 }
 
 func (d *Definition) String() string {
@@ -112,6 +115,7 @@ func makeDefinition(in *ast.Definition) (*Definition, error) {
 		Description:   in.Description,
 		Interfaces:    in.Interfaces,
 		PossibleTypes: in.Types,
+		Position:      in.Position,
 	}
 
 	if in.Kind == ast.Object || in.Kind == ast.Interface || in.Kind == ast.InputObject {
@@ -122,7 +126,7 @@ func makeDefinition(in *ast.Definition) (*Definition, error) {
 		def.Fields = fields
 	}
 
-	directives, err := makeDirectiveList(in.Directives)
+	directives, err := MakeDirectiveList(in.Directives)
 	if err != nil {
 		return nil, err
 	}
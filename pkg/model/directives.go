@@ -1,6 +1,13 @@
 package model
 
-import "github.com/vektah/gqlparser/v2/ast"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
 
 // Directive represents a specific application site / instantiation of a directive.
 //
@@ -14,6 +21,116 @@ type Directive struct {
 // DirectiveList represents a list of directives all applied at the same application site.
 type DirectiveList []*Directive
 
+// DefaultDeprecationReason is the reason assumed for @deprecated directives which don't specify one, per the
+// GraphQL spec.
+const DefaultDeprecationReason = "No longer supported"
+
+// DeprecationReason returns the reason given by an applied @deprecated directive, and whether the directive
+// is present in the list at all.
+func (dl DirectiveList) DeprecationReason() (string, bool) {
+	d := dl.Named("deprecated")
+	if d == nil {
+		return "", false
+	}
+	for _, arg := range d.Arguments {
+		if reason, ok := arg.Value.(string); arg.Name == "reason" && ok {
+			return reason, true
+		}
+	}
+	return DefaultDeprecationReason, true
+}
+
+// Format returns d as it would be written at an application site, like '@auth(role: "admin")'.
+func (d *Directive) Format() (string, error) {
+	args, err := d.Arguments.Format()
+	if err != nil {
+		return "", err
+	}
+	return "@" + d.Name + args, nil
+}
+
+// Named returns the first directive in the list with the given name, or nil if there is none.
+func (dl DirectiveList) Named(name string) *Directive {
+	for _, d := range dl {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// Contains returns true if any directive in the list matches the given pattern. See Directive.Matches.
+func (dl DirectiveList) Contains(pattern *Directive) bool {
+	for _, d := range dl {
+		if d.Matches(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns true if d has the same name as the given pattern, and has each of the pattern's arguments
+// with an equal value. Arguments not given in the pattern may have any value.
+//
+// Following GraphQL's input coercion rules, a non-list pattern argument also matches a list value containing it.
+func (d *Directive) Matches(pattern *Directive) bool {
+	if d.Name != pattern.Name {
+		return false
+	}
+
+	for _, patternArg := range pattern.Arguments {
+		var found bool
+		for _, arg := range d.Arguments {
+			if arg.Name == patternArg.Name && valueMatches(arg.Value, patternArg.Value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func valueMatches(actual, pattern Value) bool {
+	if reflect.DeepEqual(actual, pattern) {
+		return true
+	}
+
+	if items, ok := actual.([]any); ok {
+		if _, patternIsList := pattern.([]any); !patternIsList {
+			for _, item := range items {
+				if reflect.DeepEqual(item, pattern) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// ParseDirective parses a single directive application in GraphQL SDL notation, like 'key(fields: "id")'.
+// The leading '@' is optional.
+func ParseDirective(raw string) (*Directive, error) {
+	doc, err := parser.ParseSchema(&ast.Source{
+		Name:  "directive",
+		Input: "scalar Placeholder @" + strings.TrimPrefix(strings.TrimSpace(raw), "@"),
+	})
+	if err != nil || len(doc.Definitions) != 1 || len(doc.Definitions[0].Directives) != 1 {
+		return nil, fmt.Errorf("invalid directive '%s': expected a single directive, like 'name' or 'name(arg: \"value\")'", raw)
+	}
+
+	directives, err := MakeDirectiveList(doc.Definitions[0].Directives)
+	if err != nil {
+		return nil, fmt.Errorf("invalid directive '%s': %w", raw, err)
+	}
+
+	return directives[0], nil
+}
+
 // DirectiveDefinition represents the definition of a directive.
 // Based on the __Directive introspection type defined here: https://spec.graphql.org/October2021/#sec-The-__Directive-Type
 type DirectiveDefinition struct {
@@ -22,6 +139,7 @@ type DirectiveDefinition struct {
 	Arguments    ArgumentDefinitionList  `json:"arguments,omitempty"`
 	Locations    []ast.DirectiveLocation `json:"locations"`
 	IsRepeatable bool                    `json:"repeatable"`
+	Position     *ast.Position           `json:"-"`
 }
 
 // DirectiveDefinitionList represents a list of directive definitions.
@@ -39,10 +157,12 @@ func makeDirectiveDefinition(in *ast.DirectiveDefinition) (*DirectiveDefinition,
 		Arguments:    args,
 		Locations:    in.Locations,
 		IsRepeatable: in.IsRepeatable,
+		Position:     in.Position,
 	}, nil
 }
 
-func makeDirectiveList(in ast.DirectiveList) (DirectiveList, error) {
+// MakeDirectiveList converts a list of directives applied within an ast.Schema into a DirectiveList.
+func MakeDirectiveList(in ast.DirectiveList) (DirectiveList, error) {
 	var out DirectiveList
 	for _, d := range in {
 		args, err := makeArgumentList(d.Arguments)
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectiveMatches(t *testing.T) {
+	applied := &Directive{
+		Name: "auth",
+		Arguments: ArgumentList{
+			{Name: "role", Value: "admin"},
+			{Name: "scopes", Value: []any{"read", "write"}},
+			{Name: "level", Value: int64(2)},
+		},
+	}
+
+	for _, tc := range []struct {
+		pattern  string
+		expected bool
+	}{
+		{pattern: "auth", expected: true},
+		{pattern: "@auth", expected: true},
+		{pattern: "internal", expected: false},
+		{pattern: `auth(role: "admin")`, expected: true},
+		{pattern: `auth(role: "viewer")`, expected: false},
+		{pattern: `auth(role: "admin", level: 2)`, expected: true},
+		{pattern: `auth(level: 3)`, expected: false},
+		{pattern: `auth(scopes: "write")`, expected: true},
+		{pattern: `auth(scopes: ["read", "write"])`, expected: true},
+		{pattern: `auth(scopes: ["write"])`, expected: false},
+		{pattern: `auth(missing: true)`, expected: false},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			pattern, err := ParseDirective(tc.pattern)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, applied.Matches(pattern))
+			assert.Equal(t, tc.expected, DirectiveList{applied}.Contains(pattern))
+		})
+	}
+}
+
+func TestParseDirectiveErrors(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"auth(",
+		"auth internal",
+		"@auth @internal",
+	} {
+		t.Run(raw, func(t *testing.T) {
+			_, err := ParseDirective(raw)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDeprecationReason(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		directives DirectiveList
+		reason     string
+		deprecated bool
+	}{
+		{
+			name:       "not deprecated",
+			directives: DirectiveList{{Name: "internal"}},
+		},
+		{
+			name:       "explicit reason",
+			directives: DirectiveList{{Name: "deprecated", Arguments: ArgumentList{{Name: "reason", Value: "Use `bar` instead."}}}},
+			reason:     "Use `bar` instead.",
+			deprecated: true,
+		},
+		{
+			name:       "default reason",
+			directives: DirectiveList{{Name: "deprecated"}},
+			reason:     DefaultDeprecationReason,
+			deprecated: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, deprecated := tc.directives.DeprecationReason()
+			assert.Equal(t, tc.reason, reason)
+			assert.Equal(t, tc.deprecated, deprecated)
+		})
+	}
+}
+
+func TestDirectiveFormat(t *testing.T) {
+	for _, tc := range []struct {
+		directive *Directive
+		expected  string
+	}{
+		{
+			directive: &Directive{Name: "internal"},
+			expected:  "@internal",
+		},
+		{
+			directive: &Directive{Name: "auth", Arguments: ArgumentList{
+				{Name: "role", Value: "admin"},
+				{Name: "scopes", Value: []any{"read", "write"}},
+				{Name: "level", Value: json.RawMessage(`"HIGH"`)},
+			}},
+			expected: `@auth(role: "admin", scopes: ["read","write"], level: HIGH)`,
+		},
+	} {
+		actual, err := tc.directive.Format()
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, actual)
+	}
+}
@@ -1,6 +1,11 @@
 package model
 
-import "github.com/vektah/gqlparser/v2/ast"
+import (
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
 
 // EnumValueDefinition represents a single possible value for a GraphQL enum.
 // Based on the __EnumValue introspection type specified here: https://spec.graphql.org/October2021/#sec-The-__EnumValue-Type
@@ -9,11 +14,27 @@ type EnumValueDefinition struct {
 	Description string        `json:"description,omitempty"`
 	Name        string        `json:"name"`
 	Directives  DirectiveList `json:"directives,omitempty"`
+	Position    *ast.Position `json:"-"`
 }
 
 // EnumValueList represents a set of possible enum values for a single enum.
 type EnumValueList []*EnumValueDefinition
 
+func (evl EnumValueList) Sort() {
+	slices.SortFunc(evl, func(a, b *EnumValueDefinition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+func (evl EnumValueList) Named(name string) *EnumValueDefinition {
+	for _, ev := range evl {
+		if ev.Name == name {
+			return ev
+		}
+	}
+	return nil
+}
+
 func makeEnumValueList(in ast.EnumValueList) (EnumValueList, error) {
 	var result EnumValueList
 	for _, ev := range in {
@@ -27,7 +48,7 @@ func makeEnumValueList(in ast.EnumValueList) (EnumValueList, error) {
 }
 
 func makeEnumValue(in *ast.EnumValueDefinition) (*EnumValueDefinition, error) {
-	directives, err := makeDirectiveList(in.Directives)
+	directives, err := MakeDirectiveList(in.Directives)
 	if err != nil {
 		return nil, err
 	}
@@ -35,5 +56,6 @@ func makeEnumValue(in *ast.EnumValueDefinition) (*EnumValueDefinition, error) {
 		Name:        in.Name,
 		Description: in.Description,
 		Directives:  directives,
+		Position:    in.Position,
 	}, nil
 }
@@ -24,6 +24,7 @@ type FieldDefinition struct {
 	Arguments    ArgumentDefinitionList `json:"arguments,omitempty"`    // only for fields
 	DefaultValue Value                  `json:"defaultValue,omitempty"` // only for input values
 	Directives   DirectiveList          `json:"directives,omitempty"`
+	Position     *ast.Position          `json:"-"`
 }
 
 // FieldDefinitionList represents a set of fields definitions on the same object, interface, or input type.
@@ -85,7 +86,7 @@ func makeFieldDefinitionList(in ast.FieldList) (FieldDefinitionList, error) {
 			return nil, err
 		}
 
-		directives, err := makeDirectiveList(f.Directives)
+		directives, err := MakeDirectiveList(f.Directives)
 		if err != nil {
 			return nil, err
 		}
@@ -97,6 +98,7 @@ func makeFieldDefinitionList(in ast.FieldList) (FieldDefinitionList, error) {
 			Arguments:    args,
 			Directives:   directives,
 			DefaultValue: defaultValue,
+			Position:     f.Position,
 		})
 	}
 	return result, nil
@@ -0,0 +1,252 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ToAst converts s back into an *ast.Schema, which may then be printed as GraphQL SDL using gqlparser's
+// formatter package. This is the inverse of MakeSchema, and preserves descriptions, default values, applied
+// directives, and root operation types.
+//
+// Since the model is commonly filtered (for example, to remove built-in types, or types which aren't
+// reachable from a given root), the result may refer to types which are not defined within it.
+func (s *Schema) ToAst() (*ast.Schema, error) {
+	out := &ast.Schema{
+		Description: s.Description,
+		Types:       map[string]*ast.Definition{},
+		Directives:  map[string]*ast.DirectiveDefinition{},
+	}
+
+	for name, def := range s.Types {
+		astDef, err := def.toAst()
+		if err != nil {
+			return nil, err
+		}
+		out.Types[name] = astDef
+	}
+
+	astutil.LinkPossibleTypes(out)
+
+	for _, dd := range s.Directives {
+		args, err := dd.Arguments.toAst()
+		if err != nil {
+			return nil, err
+		}
+
+		// gqlparser's formatter expects directive definitions to have a position, which won't be the case for
+		// schemas which weren't loaded from SDL.
+		position := dd.Position
+		if position == nil {
+			position = &ast.Position{Src: &ast.Source{}}
+		}
+
+		out.Directives[dd.Name] = &ast.DirectiveDefinition{
+			Description:  dd.Description,
+			Name:         dd.Name,
+			Arguments:    args,
+			Locations:    dd.Locations,
+			IsRepeatable: dd.IsRepeatable,
+			Position:     position,
+		}
+	}
+
+	out.Query = out.Types[s.QueryTypeName]
+	out.Mutation = out.Types[s.MutationTypeName]
+	out.Subscription = out.Types[s.SubscriptionTypeName]
+
+	return out, nil
+}
+
+func (d *Definition) toAst() (*ast.Definition, error) {
+	out := &ast.Definition{
+		Kind:        d.Kind,
+		Name:        d.Name,
+		Description: d.Description,
+		Interfaces:  d.Interfaces,
+		Position:    d.Position,
+	}
+
+	if d.Kind == ast.Union {
+		out.Types = d.PossibleTypes
+	}
+
+	directives, err := d.Directives.toAst()
+	if err != nil {
+		return nil, err
+	}
+	out.Directives = directives
+
+	for _, f := range d.Fields {
+		field, err := f.toAst()
+		if err != nil {
+			return nil, fmt.Errorf("field '%s.%s': %w", d.Name, f.Name, err)
+		}
+		out.Fields = append(out.Fields, field)
+	}
+
+	for _, ev := range d.EnumValues {
+		directives, err := ev.Directives.toAst()
+		if err != nil {
+			return nil, fmt.Errorf("enum value '%s.%s': %w", d.Name, ev.Name, err)
+		}
+		out.EnumValues = append(out.EnumValues, &ast.EnumValueDefinition{
+			Description: ev.Description,
+			Name:        ev.Name,
+			Directives:  directives,
+			Position:    ev.Position,
+		})
+	}
+
+	return out, nil
+}
+
+func (f *FieldDefinition) toAst() (*ast.FieldDefinition, error) {
+	args, err := f.Arguments.toAst()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultValue, err := ValueToAst(f.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := f.Directives.toAst()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.FieldDefinition{
+		Description:  f.Description,
+		Name:         f.Name,
+		Arguments:    args,
+		DefaultValue: defaultValue,
+		Type:         f.Type.toAst(),
+		Directives:   directives,
+		Position:     f.Position,
+	}, nil
+}
+
+func (adl ArgumentDefinitionList) toAst() (ast.ArgumentDefinitionList, error) {
+	var out ast.ArgumentDefinitionList
+	for _, a := range adl {
+		defaultValue, err := ValueToAst(a.DefaultValue)
+		if err != nil {
+			return nil, fmt.Errorf("argument '%s': %w", a.Name, err)
+		}
+
+		directives, err := a.Directives.toAst()
+		if err != nil {
+			return nil, fmt.Errorf("argument '%s': %w", a.Name, err)
+		}
+
+		out = append(out, &ast.ArgumentDefinition{
+			Description:  a.Description,
+			Name:         a.Name,
+			DefaultValue: defaultValue,
+			Type:         a.Type.toAst(),
+			Directives:   directives,
+			Position:     a.Position,
+		})
+	}
+	return out, nil
+}
+
+func (dl DirectiveList) toAst() (ast.DirectiveList, error) {
+	var out ast.DirectiveList
+	for _, d := range dl {
+		var args ast.ArgumentList
+		for _, arg := range d.Arguments {
+			value, err := ValueToAst(arg.Value)
+			if err != nil {
+				return nil, fmt.Errorf("directive '@%s': %w", d.Name, err)
+			}
+			args = append(args, &ast.Argument{
+				Name:  arg.Name,
+				Value: value,
+			})
+		}
+		out = append(out, &ast.Directive{
+			Name:      d.Name,
+			Arguments: args,
+		})
+	}
+	return out, nil
+}
+
+func (t *Type) toAst() *ast.Type {
+	switch t.Kind {
+	case NonNullKind:
+		inner := t.OfType.toAst()
+		inner.NonNull = true
+		return inner
+	case ListKind:
+		return ast.ListType(t.OfType.toAst(), nil)
+	default:
+		return ast.NamedType(t.Name, nil)
+	}
+}
+
+// ValueToAst converts the given value back into an *ast.Value, and is the inverse of makeValue. Since makeValue
+// represents enum and null values as their JSON encodings, any json.RawMessage value is assumed to be one of those.
+// It returns nil for a nil value.
+func ValueToAst(v Value) (*ast.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return &ast.Value{Kind: ast.StringValue, Raw: v}, nil
+	case bool:
+		return &ast.Value{Kind: ast.BooleanValue, Raw: strconv.FormatBool(v)}, nil
+	case int64:
+		return &ast.Value{Kind: ast.IntValue, Raw: strconv.FormatInt(v, 10)}, nil
+	case int:
+		return &ast.Value{Kind: ast.IntValue, Raw: strconv.Itoa(v)}, nil
+	case float64:
+		// makeValue parses floats with 32-bit precision, so format them the same way to avoid spurious digits.
+		return &ast.Value{Kind: ast.FloatValue, Raw: strconv.FormatFloat(v, 'g', -1, 32)}, nil
+	case json.RawMessage:
+		if string(v) == "null" {
+			return &ast.Value{Kind: ast.NullValue, Raw: "null"}, nil
+		}
+		var enumValue string
+		if err := json.Unmarshal(v, &enumValue); err != nil {
+			return nil, fmt.Errorf("invalid enum value %s: %w", v, err)
+		}
+		return &ast.Value{Kind: ast.EnumValue, Raw: enumValue}, nil
+	case []any:
+		out := &ast.Value{Kind: ast.ListValue}
+		for _, item := range v {
+			child, err := ValueToAst(item)
+			if err != nil {
+				return nil, err
+			}
+			out.Children = append(out.Children, &ast.ChildValue{Value: child})
+		}
+		return out, nil
+	case map[string]any:
+		var keys []string
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		out := &ast.Value{Kind: ast.ObjectValue}
+		for _, k := range keys {
+			child, err := ValueToAst(v[k])
+			if err != nil {
+				return nil, err
+			}
+			out.Children = append(out.Children, &ast.ChildValue{Name: k, Value: child})
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", v)
+	}
+}
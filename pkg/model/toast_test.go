@@ -0,0 +1,103 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+func TestToAstRoundTrip(t *testing.T) {
+	root := "testdata/cases"
+	entries, err := os.ReadDir(root)
+	assert.NoError(t, err)
+
+	for _, ent := range entries {
+		if !ent.IsDir() {
+			continue
+		}
+
+		t.Run(ent.Name(), func(t *testing.T) {
+			raw, err := os.ReadFile(path.Join(root, ent.Name(), "in.graphql"))
+			assert.NoError(t, err)
+			assertRoundTrips(t, string(raw))
+		})
+	}
+}
+
+func TestToAstValues(t *testing.T) {
+	assertRoundTrips(t, valuesSdl)
+}
+
+const valuesSdl = `schema {
+	query: RootQuery
+	mutation: RootMutation
+}
+
+directive @meta(values: [Value!], extra: Extra) repeatable on FIELD_DEFINITION | ARGUMENT_DEFINITION
+
+enum Value { A, B }
+
+input Extra {
+	name: String = "with \"quotes\""
+	ratio: Float = 0.1
+	count: Int = -3
+	enabled: Boolean = false
+	value: Value = B
+	nested: Extra = null
+}
+
+"""
+The root query type.
+"""
+type RootQuery {
+	"Looks things up."
+	lookup(
+		"The filter."
+		filter: Extra = {name: "x", count: 1, value: A, nested: {enabled: true}}
+		values: [Value!]! = [A, B] @meta(values: A)
+	): String @meta(values: [A, B], extra: {ratio: 1.5}) @meta(extra: null)
+}
+
+type RootMutation {
+	noop: Boolean
+}`
+
+func assertRoundTrips(t *testing.T, sdl string) {
+	original := loadAndSerialize(t, sdl)
+
+	s, err := gqlparser.LoadSchema(&ast.Source{Name: "input", Input: sdl})
+	assert.NoError(t, err)
+	ss, err := MakeSchema(s)
+	assert.NoError(t, err)
+	ss.FilterBuiltins()
+
+	converted, err := ss.ToAst()
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatSchema(converted)
+
+	assert.Equal(t, original, loadAndSerialize(t, buf.String()), "formatted SDL:\n%s", buf.String())
+}
+
+func loadAndSerialize(t *testing.T, sdl string) string {
+	s, err := gqlparser.LoadSchema(&ast.Source{Name: "input", Input: sdl})
+	if !assert.NoError(t, err) {
+		return ""
+	}
+
+	ss, err := MakeSchema(s)
+	assert.NoError(t, err)
+	ss.FilterBuiltins()
+
+	out, err := json.MarshalIndent(ss, "", "  ")
+	assert.NoError(t, err)
+	return string(out)
+}
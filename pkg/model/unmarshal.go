@@ -0,0 +1,309 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// UnmarshalJSON reads a schema in the JSON format produced by MarshalJSON, for example after it has been
+// transformed with a tool like jq.
+//
+// The 'typeName' and 'underlyingTypeName' keys of fields and arguments are ignored in favor of the structured
+// 'type' key. Since JSON can't distinguish between enum values and strings, default values and directive
+// arguments are interpreted according to their declared types, where those are known.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var in struct {
+		Description          string                  `json:"description"`
+		Types                DefinitionList          `json:"types"`
+		QueryTypeName        string                  `json:"queryTypeName"`
+		MutationTypeName     string                  `json:"mutationTypeName"`
+		SubscriptionTypeName string                  `json:"subscriptionTypeName"`
+		Directives           DirectiveDefinitionList `json:"directives"`
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*s = Schema{
+		Description:          in.Description,
+		Types:                in.Types.ToMap(),
+		QueryTypeName:        in.QueryTypeName,
+		MutationTypeName:     in.MutationTypeName,
+		SubscriptionTypeName: in.SubscriptionTypeName,
+		Directives:           in.Directives,
+	}
+
+	s.resolveUnmarshalledTypes()
+	return nil
+}
+
+func (d *Definition) UnmarshalJSON(data []byte) error {
+	var in struct {
+		Kind          ast.DefinitionKind  `json:"kind"`
+		Name          string              `json:"name"`
+		Description   string              `json:"description"`
+		Directives    DirectiveList       `json:"directives"`
+		Fields        FieldDefinitionList `json:"fields"`
+		InputFields   FieldDefinitionList `json:"inputFields"`
+		Interfaces    []string            `json:"interfaces"`
+		PossibleTypes []string            `json:"possibleTypeNames"`
+		EnumValues    EnumValueList       `json:"enumValues"`
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	if in.Name == "" {
+		return fmt.Errorf("type definition is missing a name")
+	}
+
+	fields := in.Fields
+	if in.Kind == ast.InputObject {
+		fields = in.InputFields
+	}
+
+	*d = Definition{
+		Kind:          in.Kind,
+		Name:          in.Name,
+		Description:   in.Description,
+		Directives:    in.Directives,
+		Fields:        fields,
+		Interfaces:    in.Interfaces,
+		PossibleTypes: in.PossibleTypes,
+		EnumValues:    in.EnumValues,
+	}
+	return nil
+}
+
+// inputValueJSON holds the keys shared by the JSON representations of FieldDefinition and ArgumentDefinition.
+type inputValueJSON struct {
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	Type         *Type                  `json:"type"`
+	Arguments    ArgumentDefinitionList `json:"arguments"`
+	DefaultValue json.RawMessage        `json:"defaultValue"`
+	Directives   DirectiveList          `json:"directives"`
+}
+
+func (iv *inputValueJSON) unmarshal(data []byte) (Value, error) {
+	if err := json.Unmarshal(data, iv); err != nil {
+		return nil, err
+	}
+	if iv.Type == nil {
+		return nil, fmt.Errorf("'%s' is missing a type", iv.Name)
+	}
+	if iv.DefaultValue == nil {
+		return nil, nil
+	}
+	return decodeValue(iv.DefaultValue)
+}
+
+func (fd *FieldDefinition) UnmarshalJSON(data []byte) error {
+	var in inputValueJSON
+	defaultValue, err := in.unmarshal(data)
+	if err != nil {
+		return err
+	}
+
+	*fd = FieldDefinition{
+		Name:         in.Name,
+		Description:  in.Description,
+		Type:         in.Type,
+		Arguments:    in.Arguments,
+		DefaultValue: defaultValue,
+		Directives:   in.Directives,
+	}
+	return nil
+}
+
+func (a *ArgumentDefinition) UnmarshalJSON(data []byte) error {
+	var in inputValueJSON
+	defaultValue, err := in.unmarshal(data)
+	if err != nil {
+		return err
+	}
+
+	*a = ArgumentDefinition{
+		Name:         in.Name,
+		Description:  in.Description,
+		Type:         in.Type,
+		DefaultValue: defaultValue,
+		Directives:   in.Directives,
+	}
+	return nil
+}
+
+func (a *Argument) UnmarshalJSON(data []byte) error {
+	var in struct {
+		Name  string          `json:"name"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	value, err := decodeValue(in.Value)
+	if err != nil {
+		return err
+	}
+
+	*a = Argument{
+		Name:  in.Name,
+		Value: value,
+	}
+	return nil
+}
+
+// decodeValue converts a JSON-encoded value into the representation used by makeValue, except that enum values
+// are left as strings, since they can't be identified without knowing the value's type. See resolveValue.
+func decodeValue(raw json.RawMessage) (Value, error) {
+	if raw == nil {
+		return json.RawMessage("null"), nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return convertDecodedValue(v), nil
+}
+
+func convertDecodedValue(v any) Value {
+	switch v := v.(type) {
+	case nil:
+		return json.RawMessage("null")
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		var l []any
+		for _, item := range v {
+			l = append(l, convertDecodedValue(item))
+		}
+		return l
+	case map[string]any:
+		m := map[string]any{}
+		for k, item := range v {
+			m[k] = convertDecodedValue(item)
+		}
+		return m
+	default:
+		return v
+	}
+}
+
+// resolveUnmarshalledTypes updates the kinds of all type references to match the named types they refer to,
+// and re-interprets default values and directive arguments according to their declared types.
+// References to types which are not defined in the schema are left as-is.
+func (s *Schema) resolveUnmarshalledTypes() {
+	directiveDefs := map[string]*DirectiveDefinition{}
+	for _, dd := range s.Directives {
+		directiveDefs[dd.Name] = dd
+	}
+
+	resolveDirectives := func(dl DirectiveList) {
+		for _, d := range dl {
+			dd := directiveDefs[d.Name]
+			if dd == nil {
+				continue
+			}
+			for _, arg := range d.Arguments {
+				if argDef := dd.Arguments.Named(arg.Name); argDef != nil {
+					arg.Value = s.resolveValue(arg.Value, argDef.Type)
+				}
+			}
+		}
+	}
+
+	resolveArguments := func(args ArgumentDefinitionList) {
+		for _, arg := range args {
+			s.resolveTypeKind(arg.Type)
+			arg.DefaultValue = s.resolveValue(arg.DefaultValue, arg.Type)
+			resolveDirectives(arg.Directives)
+		}
+	}
+
+	for _, dd := range s.Directives {
+		resolveArguments(dd.Arguments)
+	}
+
+	for _, def := range s.Types {
+		resolveDirectives(def.Directives)
+		for _, f := range def.Fields {
+			s.resolveTypeKind(f.Type)
+			f.DefaultValue = s.resolveValue(f.DefaultValue, f.Type)
+			resolveDirectives(f.Directives)
+			resolveArguments(f.Arguments)
+		}
+		for _, ev := range def.EnumValues {
+			resolveDirectives(ev.Directives)
+		}
+	}
+}
+
+func (s *Schema) resolveTypeKind(t *Type) {
+	if t.OfType != nil {
+		s.resolveTypeKind(t.OfType)
+		return
+	}
+	if def := s.Types[t.Name]; def != nil {
+		t.Kind = TypeKind(def.Kind)
+	}
+}
+
+// resolveValue converts the given value, as returned by decodeValue, to match the given type. Strings are
+// converted to enum values where an enum is expected, and ints to floats where a float is expected.
+func (s *Schema) resolveValue(v Value, t *Type) Value {
+	if v == nil || t == nil {
+		return v
+	}
+
+	switch t.Kind {
+	case NonNullKind:
+		return s.resolveValue(v, t.OfType)
+	case ListKind:
+		if l, ok := v.([]any); ok {
+			var result []any
+			for _, item := range l {
+				result = append(result, s.resolveValue(item, t.OfType))
+			}
+			return result
+		}
+		// A single value may be given where a list is expected, per the input coercion rules.
+		return s.resolveValue(v, t.OfType)
+	}
+
+	def := s.Types[t.Name]
+	switch v := v.(type) {
+	case string:
+		if def != nil && def.Kind == ast.Enum {
+			raw, _ := json.Marshal(v)
+			return json.RawMessage(raw)
+		}
+	case int64:
+		if t.Name == "Float" {
+			return float64(v)
+		}
+	case map[string]any:
+		if def != nil && def.Kind == ast.InputObject {
+			result := map[string]any{}
+			for k, item := range v {
+				if f := def.Fields.Named(k); f != nil {
+					result[k] = s.resolveValue(item, f.Type)
+				} else {
+					result[k] = item
+				}
+			}
+			return result
+		}
+	}
+	return v
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+func TestUnmarshalJSONRoundTrip(t *testing.T) {
+	root := "testdata/cases"
+	entries, err := os.ReadDir(root)
+	assert.NoError(t, err)
+
+	for _, ent := range entries {
+		if !ent.IsDir() {
+			continue
+		}
+
+		t.Run(ent.Name(), func(t *testing.T) {
+			raw, err := os.ReadFile(path.Join(root, ent.Name(), "in.graphql"))
+			assert.NoError(t, err)
+			assertUnmarshalRoundTrips(t, string(raw))
+		})
+	}
+}
+
+func TestUnmarshalJSONValues(t *testing.T) {
+	assertUnmarshalRoundTrips(t, valuesSdl)
+}
+
+func TestUnmarshalJSONResolvesKinds(t *testing.T) {
+	var s Schema
+	err := json.Unmarshal([]byte(`{
+		"queryTypeName": "Query",
+		"types": [
+			{"kind": "ENUM", "name": "Color", "enumValues": [{"name": "RED"}, {"name": "GREEN"}]},
+			{"kind": "OBJECT", "name": "Query", "fields": [
+				{"name": "paint", "type": {"kind": "SCALAR", "name": "Color"}, "arguments": [
+					{"name": "color", "type": {"kind": "NON_NULL", "ofType": {"name": "Color"}}, "defaultValue": "RED"},
+					{"name": "label", "type": {"name": "String"}, "defaultValue": "RED"},
+					{"name": "nothing", "type": {"name": "String"}, "defaultValue": null}
+				]}
+			]}
+		]
+	}`), &s)
+	assert.NoError(t, err)
+
+	paint := s.Types["Query"].Fields.Named("paint")
+	assert.Equal(t, EnumKind, paint.Type.Kind)
+	assert.Equal(t, EnumKind, paint.Arguments.Named("color").Type.OfType.Kind)
+	assert.Equal(t, json.RawMessage(`"RED"`), paint.Arguments.Named("color").DefaultValue)
+	assert.Equal(t, "RED", paint.Arguments.Named("label").DefaultValue)
+	assert.Equal(t, json.RawMessage("null"), paint.Arguments.Named("nothing").DefaultValue)
+}
+
+func TestUnmarshalJSONMissingName(t *testing.T) {
+	var s Schema
+	err := json.Unmarshal([]byte(`{"types": [{"kind": "OBJECT"}]}`), &s)
+	assert.Error(t, err)
+}
+
+func assertUnmarshalRoundTrips(t *testing.T, sdl string) {
+	s, err := gqlparser.LoadSchema(&ast.Source{Name: "input", Input: sdl})
+	if !assert.NoError(t, err) {
+		return
+	}
+	original, err := MakeSchema(s)
+	assert.NoError(t, err)
+	original.FilterBuiltins()
+
+	raw, err := json.Marshal(original)
+	assert.NoError(t, err)
+
+	var unmarshalled Schema
+	assert.NoError(t, json.Unmarshal(raw, &unmarshalled))
+
+	reserialized, err := json.Marshal(&unmarshalled)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(raw), string(reserialized))
+
+	// JSON can't distinguish between enum values and strings, so compare the SDL forms too.
+	assert.Equal(t, formatModel(t, original), formatModel(t, &unmarshalled))
+}
+
+func formatModel(t *testing.T, s *Schema) string {
+	converted, err := s.ToAst()
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatSchema(converted)
+	return buf.String()
+}
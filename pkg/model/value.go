@@ -8,8 +8,25 @@ import (
 	"github.com/vektah/gqlparser/v2/ast"
 )
 
+// Value represents a GraphQL input value, such as a default value or directive argument. Ints are represented
+// as int64, floats as float64, strings as string, and booleans as bool. Enum values and nulls are represented as
+// their JSON encodings, using json.RawMessage, so that they can be distinguished from strings. Lists are
+// represented as []any, and input objects as map[string]any.
 type Value any
 
+// FormatValue returns the given value in GraphQL syntax, as it would be written in SDL. A nil value is formatted
+// as null.
+func FormatValue(v Value) (string, error) {
+	if v == nil {
+		return "null", nil
+	}
+	astValue, err := ValueToAst(v)
+	if err != nil {
+		return "", err
+	}
+	return astValue.String(), nil
+}
+
 func makeValue(in *ast.Value) (Value, error) {
 	if in == nil {
 		return nil, nil
@@ -25,9 +42,10 @@ func makeValue(in *ast.Value) (Value, error) {
 	case ast.BooleanValue:
 		return strconv.ParseBool(in.Raw)
 	case ast.NullValue:
-		return json.Marshal(nil)
+		return json.RawMessage("null"), nil
 	case ast.EnumValue:
-		return json.Marshal(in.Raw)
+		raw, err := json.Marshal(in.Raw)
+		return json.RawMessage(raw), err
 	case ast.ListValue:
 		var l []any
 		for _, cv := range in.Children {
@@ -0,0 +1,135 @@
+package operations
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+
+	// Blank import is used to load up the validator rules.
+	_ "github.com/vektah/gqlparser/v2/validator/rules"
+)
+
+// Parse parses each of the given sources as a GraphQL operation document, and merges the results
+// into a single *ast.QueryDocument, so that fragments defined in one source may be used from another.
+//
+// Parsing continues past syntax errors in individual sources, so that all of them can be reported at once.
+func Parse(sources []*ast.Source) (*ast.QueryDocument, gqlerror.List) {
+	var errs gqlerror.List
+	merged := &ast.QueryDocument{}
+	for _, src := range sources {
+		doc, err := parser.ParseQuery(src)
+		if err != nil {
+			var gqlErr *gqlerror.Error
+			if !errors.As(err, &gqlErr) {
+				gqlErr = gqlerror.Wrap(err)
+				gqlErr.SetFile(src.Name)
+			}
+			errs = append(errs, gqlErr)
+			continue
+		}
+		merged.Operations = append(merged.Operations, doc.Operations...)
+		merged.Fragments = append(merged.Fragments, doc.Fragments...)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return merged, nil
+}
+
+// Validate runs the validation rules from the GraphQL spec against the given (possibly merged) operation
+// document, and returns any errors found, sorted by location.
+//
+// Since an anonymous operation is only required to be the only operation within its own source file,
+// the LoneAnonymousOperation rule is applied per-file rather than across the whole merged document, and
+// anonymous operations in different files are not considered to have conflicting names.
+// As a side effect of validation, the selections in doc are annotated with their schema definitions.
+func Validate(schema *ast.Schema, doc *ast.QueryDocument) gqlerror.List {
+	opsPerFile := map[string]int{}
+	anonymousOps := map[errorPosition]bool{}
+	for _, op := range doc.Operations {
+		opsPerFile[sourceName(op.Position)]++
+		if op.Name == "" {
+			anonymousOps[positionOf(op.Position)] = true
+		}
+	}
+
+	var result gqlerror.List
+	for _, err := range validator.Validate(schema, doc) {
+		if err.Rule == "LoneAnonymousOperation" && opsPerFile[ErrorFile(err)] <= 1 {
+			continue
+		}
+		if err.Rule == "UniqueOperationNames" && anonymousOps[errorPositionOf(err)] {
+			continue
+		}
+		result = append(result, err)
+	}
+
+	SortErrors(result)
+	return result
+}
+
+// SortErrors orders the given errors by file name, line, and column.
+func SortErrors(errs gqlerror.List) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i], errs[j]
+		if fa, fb := ErrorFile(a), ErrorFile(b); fa != fb {
+			return fa < fb
+		}
+		la, lb := errorLocation(a), errorLocation(b)
+		if la.Line != lb.Line {
+			return la.Line < lb.Line
+		}
+		return la.Column < lb.Column
+	})
+}
+
+// ErrorFile returns the name of the source file associated with the given error, if any.
+func ErrorFile(err *gqlerror.Error) string {
+	file, _ := err.Extensions["file"].(string)
+	return file
+}
+
+func errorLocation(err *gqlerror.Error) gqlerror.Location {
+	if len(err.Locations) == 0 {
+		return gqlerror.Location{}
+	}
+	return err.Locations[0]
+}
+
+type errorPosition struct {
+	file         string
+	line, column int
+}
+
+func positionOf(pos *ast.Position) errorPosition {
+	if pos == nil {
+		return errorPosition{}
+	}
+	return errorPosition{
+		file:   sourceName(pos),
+		line:   pos.Line,
+		column: pos.Column,
+	}
+}
+
+func errorPositionOf(err *gqlerror.Error) errorPosition {
+	loc := errorLocation(err)
+	return errorPosition{
+		file:   ErrorFile(err),
+		line:   loc.Line,
+		column: loc.Column,
+	}
+}
+
+func sourceName(pos *ast.Position) string {
+	if pos == nil || pos.Src == nil {
+		return ""
+	}
+	return pos.Src.Name
+}
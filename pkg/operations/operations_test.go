@@ -0,0 +1,99 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestValidate(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{
+		Name: "schema",
+		Input: `type Query {
+			person(name: String!): Person
+		}
+
+		type Person {
+			name: String
+			friends: [Person]
+		}`,
+	})
+
+	for _, tc := range []struct {
+		name     string
+		sources  map[string]string
+		expected []string
+	}{
+		{
+			name: "fragments shared across files",
+			sources: map[string]string{
+				"a.graphql": `query A { person(name: "a") { ...PersonDetails } }`,
+				"b.graphql": `fragment PersonDetails on Person { name }`,
+			},
+		},
+		{
+			name: "anonymous operations in separate files",
+			sources: map[string]string{
+				"a.graphql": `{ person(name: "a") { name } }`,
+				"b.graphql": `{ person(name: "b") { friends { name } } }`,
+			},
+		},
+		{
+			name: "anonymous operation alongside another in the same file",
+			sources: map[string]string{
+				"a.graphql": `{ person(name: "a") { name } }
+				query B { person(name: "b") { name } }`,
+			},
+			expected: []string{
+				"a.graphql:1: This anonymous operation must be the only defined operation.",
+			},
+		},
+		{
+			name: "invalid field",
+			sources: map[string]string{
+				"a.graphql": `query A { person { age } }`,
+			},
+			expected: []string{
+				`a.graphql:1: Field "person" argument "name" of type "String!" is required, but it was not provided.`,
+				`a.graphql:1: Cannot query field "age" on type "Person". Did you mean "name"?`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var sources []*ast.Source
+			for _, name := range []string{"a.graphql", "b.graphql"} {
+				if input, ok := tc.sources[name]; ok {
+					sources = append(sources, &ast.Source{Name: name, Input: input})
+				}
+			}
+
+			doc, errs := Parse(sources)
+			assert.Empty(t, errs)
+
+			var actual []string
+			for _, err := range Validate(schema, doc) {
+				actual = append(actual, err.Error())
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, errs := Parse([]*ast.Source{
+		{Name: "a.graphql", Input: `query A { person(name: "a") { name }`},
+		{Name: "b.graphql", Input: `query B { person(name: "b") { name } }`},
+		{Name: "c.graphql", Input: `fragment on Person { name }`},
+	})
+
+	var actual []string
+	for _, err := range errs {
+		actual = append(actual, err.Error())
+	}
+	assert.Equal(t, []string{
+		"a.graphql:1: Expected Name, found <EOF>",
+		"c.graphql:1: Unexpected Name \"on\"",
+	}, actual)
+}
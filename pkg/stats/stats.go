@@ -0,0 +1,256 @@
+// Package stats computes summary statistics describing the size and shape of a schema.
+package stats
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/benweint/gquil/pkg/graph"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Ratio describes how many of a set of schema elements have some property.
+type Ratio struct {
+	Count   int     `json:"count"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+func (r Ratio) String() string {
+	return fmt.Sprintf("%d/%d (%.1f%%)", r.Count, r.Total, r.Percent)
+}
+
+func (r *Ratio) add(hasProperty bool) {
+	r.Total++
+	if hasProperty {
+		r.Count++
+	}
+	r.Percent = 100 * float64(r.Count) / float64(r.Total)
+}
+
+// Totals counts the elements of each kind in a schema.
+type Totals struct {
+	Types       int `json:"types"`
+	Fields      int `json:"fields"`
+	InputFields int `json:"inputFields"`
+	Arguments   int `json:"arguments"`
+	EnumValues  int `json:"enumValues"`
+	Directives  int `json:"directives"`
+}
+
+// KindCount is the number of types of a given kind.
+type KindCount struct {
+	Kind  ast.DefinitionKind `json:"kind"`
+	Count int                `json:"count"`
+}
+
+// Deprecations describes what fraction of each kind of deprecatable element is deprecated.
+type Deprecations struct {
+	Fields      Ratio `json:"fields"`
+	InputFields Ratio `json:"inputFields"`
+	Arguments   Ratio `json:"arguments"`
+	EnumValues  Ratio `json:"enumValues"`
+}
+
+// Descriptions describes what fraction of each kind of element has a description.
+type Descriptions struct {
+	Types       Ratio `json:"types"`
+	Fields      Ratio `json:"fields"`
+	InputFields Ratio `json:"inputFields"`
+	Arguments   Ratio `json:"arguments"`
+	EnumValues  Ratio `json:"enumValues"`
+}
+
+// DirectiveUsage is the number of times a directive is applied within a schema.
+type DirectiveUsage struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// RootDepth describes the types reachable from one of the root operation types. See graph.Graph.Depths for
+// the definition of depth.
+type RootDepth struct {
+	Operation      ast.Operation `json:"operation"`
+	TypeName       string        `json:"typeName"`
+	ReachableTypes int           `json:"reachableTypes"`
+	MaxDepth       int           `json:"maxDepth"`
+	AverageDepth   float64       `json:"averageDepth"`
+}
+
+// TypeStats describes a single type. Fields counts fields, input fields, enum values, or possible types,
+// depending on the kind of the type.
+type TypeStats struct {
+	Name   string             `json:"name"`
+	Kind   ast.DefinitionKind `json:"kind"`
+	Fields int                `json:"fields"`
+	graph.Degree
+}
+
+// Report contains the statistics for a schema.
+type Report struct {
+	Totals          Totals           `json:"totals"`
+	Kinds           []KindCount      `json:"kinds"`
+	Deprecated      Deprecations     `json:"deprecated"`
+	Descriptions    Descriptions     `json:"descriptions"`
+	DirectiveUsages []DirectiveUsage `json:"directiveUsages"`
+	Roots           []RootDepth      `json:"roots"`
+	Types           []TypeStats      `json:"types"`
+}
+
+// kindOrder is the order in which kinds are listed in a Report.
+var kindOrder = []ast.DefinitionKind{
+	ast.Object,
+	ast.Interface,
+	ast.Union,
+	ast.Enum,
+	ast.InputObject,
+	ast.Scalar,
+}
+
+// Compute returns the statistics for the given schema. Any types which should not be counted (for example,
+// built-in types) should be filtered out of the schema beforehand.
+func Compute(s *model.Schema) *Report {
+	r := &Report{
+		DirectiveUsages: []DirectiveUsage{},
+		Roots:           []RootDepth{},
+		Types:           []TypeStats{},
+	}
+
+	directiveCounts := map[string]int{}
+	countDirectives := func(dl model.DirectiveList) {
+		for _, d := range dl {
+			directiveCounts[d.Name]++
+		}
+	}
+
+	kindCounts := map[ast.DefinitionKind]int{}
+	for _, def := range s.Types.ToSortedList() {
+		r.Totals.Types++
+		kindCounts[def.Kind]++
+		r.Descriptions.Types.add(def.Description != "")
+		countDirectives(def.Directives)
+
+		for _, f := range def.Fields {
+			isDeprecated := f.Directives.Named("deprecated") != nil
+			if def.Kind == ast.InputObject {
+				r.Totals.InputFields++
+				r.Deprecated.InputFields.add(isDeprecated)
+				r.Descriptions.InputFields.add(f.Description != "")
+			} else {
+				r.Totals.Fields++
+				r.Deprecated.Fields.add(isDeprecated)
+				r.Descriptions.Fields.add(f.Description != "")
+			}
+			countDirectives(f.Directives)
+
+			for _, arg := range f.Arguments {
+				r.Totals.Arguments++
+				r.Deprecated.Arguments.add(arg.Directives.Named("deprecated") != nil)
+				r.Descriptions.Arguments.add(arg.Description != "")
+				countDirectives(arg.Directives)
+			}
+		}
+
+		for _, ev := range def.EnumValues {
+			r.Totals.EnumValues++
+			r.Deprecated.EnumValues.add(ev.Directives.Named("deprecated") != nil)
+			r.Descriptions.EnumValues.add(ev.Description != "")
+			countDirectives(ev.Directives)
+		}
+	}
+	r.Totals.Directives = len(s.Directives)
+
+	for _, kind := range kindOrder {
+		r.Kinds = append(r.Kinds, KindCount{Kind: kind, Count: kindCounts[kind]})
+	}
+
+	for name, count := range directiveCounts {
+		r.DirectiveUsages = append(r.DirectiveUsages, DirectiveUsage{Name: name, Count: count})
+	}
+	sort.Slice(r.DirectiveUsages, func(i, j int) bool {
+		a, b := r.DirectiveUsages[i], r.DirectiveUsages[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Name < b.Name
+	})
+
+	g := graph.MakeGraph(s)
+	roots := []struct {
+		operation ast.Operation
+		typeName  string
+	}{
+		{ast.Query, s.QueryTypeName},
+		{ast.Mutation, s.MutationTypeName},
+		{ast.Subscription, s.SubscriptionTypeName},
+	}
+	for _, root := range roots {
+		if _, ok := s.Types[root.typeName]; !ok {
+			continue
+		}
+		r.Roots = append(r.Roots, computeRootDepth(g, root.operation, root.typeName))
+	}
+
+	degrees := g.Degrees()
+	for _, def := range s.Types.ToSortedList() {
+		r.Types = append(r.Types, TypeStats{
+			Name:   def.Name,
+			Kind:   def.Kind,
+			Fields: len(def.Fields) + len(def.EnumValues) + unionMembers(def),
+			Degree: degrees[def.Name],
+		})
+	}
+
+	return r
+}
+
+func unionMembers(def *model.Definition) int {
+	if def.Kind != ast.Union {
+		return 0
+	}
+	return len(def.PossibleTypes)
+}
+
+func computeRootDepth(g *graph.Graph, operation ast.Operation, typeName string) RootDepth {
+	result := RootDepth{
+		Operation: operation,
+		TypeName:  typeName,
+	}
+
+	depths := g.Depths(typeName)
+	total := 0
+	for name, depth := range depths {
+		if name == typeName {
+			continue
+		}
+		result.ReachableTypes++
+		total += depth
+		if depth > result.MaxDepth {
+			result.MaxDepth = depth
+		}
+	}
+	if result.ReachableTypes > 0 {
+		result.AverageDepth = float64(total) / float64(result.ReachableTypes)
+	}
+
+	return result
+}
+
+// Largest returns up to n types from the report, in descending order of the value returned by the given
+// function, with ties broken by name. Types for which the value is zero are omitted.
+func (r *Report) Largest(n int, value func(TypeStats) int) []TypeStats {
+	var result []TypeStats
+	for _, t := range r.Types {
+		if value(t) > 0 {
+			result = append(result, t)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return value(result[i]) > value(result[j])
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/benweint/gquil/pkg/graph"
+	"github.com/benweint/gquil/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestCompute(t *testing.T) {
+	raw, err := gqlparser.LoadSchema(&ast.Source{Name: "testcase", Input: `
+		directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT
+
+		type Query {
+			"The current user."
+			viewer: User @tag(name: "a")
+			search(filter: Filter, "Deprecated." legacy: Boolean @deprecated): [Result!]!
+		}
+
+		type Mutation {
+			follow(id: ID!): User @tag(name: "b") @tag(name: "c")
+		}
+
+		"A user."
+		type User @tag(name: "d") {
+			name: String
+			nickname: String @deprecated
+			friends: [User!]!
+		}
+
+		type Post {
+			author: User
+		}
+
+		union Result = User | Post
+
+		enum Status {
+			ACTIVE
+			"No longer used."
+			BANNED @deprecated
+		}
+
+		input Filter {
+			status: Status
+		}`,
+	})
+	assert.NoError(t, err)
+
+	s, err := model.MakeSchema(raw)
+	assert.NoError(t, err)
+	s.FilterBuiltins()
+
+	report := Compute(s)
+
+	assert.Equal(t, Totals{
+		Types:       7,
+		Fields:      7,
+		InputFields: 1,
+		Arguments:   3,
+		EnumValues:  2,
+		Directives:  1,
+	}, report.Totals)
+
+	assert.Equal(t, []KindCount{
+		{Kind: ast.Object, Count: 4},
+		{Kind: ast.Interface, Count: 0},
+		{Kind: ast.Union, Count: 1},
+		{Kind: ast.Enum, Count: 1},
+		{Kind: ast.InputObject, Count: 1},
+		{Kind: ast.Scalar, Count: 0},
+	}, report.Kinds)
+
+	assert.Equal(t, Deprecations{
+		Fields:      Ratio{Count: 1, Total: 7, Percent: 100.0 / 7},
+		InputFields: Ratio{Count: 0, Total: 1, Percent: 0},
+		Arguments:   Ratio{Count: 1, Total: 3, Percent: 100.0 / 3},
+		EnumValues:  Ratio{Count: 1, Total: 2, Percent: 50},
+	}, report.Deprecated)
+
+	assert.Equal(t, Descriptions{
+		Types:       Ratio{Count: 1, Total: 7, Percent: 100.0 / 7},
+		Fields:      Ratio{Count: 1, Total: 7, Percent: 100.0 / 7},
+		InputFields: Ratio{Count: 0, Total: 1, Percent: 0},
+		Arguments:   Ratio{Count: 1, Total: 3, Percent: 100.0 / 3},
+		EnumValues:  Ratio{Count: 1, Total: 2, Percent: 50},
+	}, report.Descriptions)
+
+	assert.Equal(t, []DirectiveUsage{
+		{Name: "tag", Count: 4},
+		{Name: "deprecated", Count: 3},
+	}, report.DirectiveUsages)
+
+	assert.Equal(t, []RootDepth{
+		{Operation: ast.Query, TypeName: "Query", ReachableTypes: 3, MaxDepth: 1, AverageDepth: 1},
+		{Operation: ast.Mutation, TypeName: "Mutation", ReachableTypes: 1, MaxDepth: 1, AverageDepth: 1},
+	}, report.Roots)
+
+	assert.Equal(t, []TypeStats{
+		{Name: "Filter", Kind: ast.InputObject, Fields: 1, Degree: graph.Degree{FanIn: 1, FanOut: 1}},
+		{Name: "Mutation", Kind: ast.Object, Fields: 1, Degree: graph.Degree{FanIn: 0, FanOut: 1}},
+		{Name: "Post", Kind: ast.Object, Fields: 1, Degree: graph.Degree{FanIn: 1, FanOut: 1}},
+		{Name: "Query", Kind: ast.Object, Fields: 2, Degree: graph.Degree{FanIn: 0, FanOut: 3}},
+		{Name: "Result", Kind: ast.Union, Fields: 2, Degree: graph.Degree{FanIn: 1, FanOut: 2}},
+		{Name: "Status", Kind: ast.Enum, Fields: 2, Degree: graph.Degree{FanIn: 1, FanOut: 0}},
+		{Name: "User", Kind: ast.Object, Fields: 3, Degree: graph.Degree{FanIn: 4, FanOut: 0}},
+	}, report.Types)
+
+	assert.Equal(t, []string{"User", "Query", "Result"}, typeNames(report.Largest(3, func(t TypeStats) int { return t.Fields })))
+	assert.Equal(t, []string{"User", "Filter", "Post", "Result", "Status"}, typeNames(report.Largest(10, func(t TypeStats) int { return t.FanIn })))
+}
+
+func typeNames(types []TypeStats) []string {
+	var result []string
+	for _, t := range types {
+		result = append(result, t.Name)
+	}
+	return result
+}
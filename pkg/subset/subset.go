@@ -0,0 +1,435 @@
+// Package subset extracts a self-consistent subset of a GraphQL schema, containing only a selected set of
+// types and fields, along with everything they depend upon.
+package subset
+
+import (
+	"strconv"
+
+	"github.com/benweint/gquil/pkg/astutil"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// PlaceholderFieldName is the name of the single field on the placeholder query root type synthesized by
+// Extract when the query root type is not part of the selection.
+const PlaceholderFieldName = "_placeholder"
+
+// Selection records the types and fields which should be included in an extracted schema.
+type Selection struct {
+	allFields map[string]bool
+	fields    map[string]map[string]bool
+}
+
+// NewSelection returns an empty Selection.
+func NewSelection() *Selection {
+	return &Selection{
+		allFields: map[string]bool{},
+		fields:    map[string]map[string]bool{},
+	}
+}
+
+// AddType adds the named type, along with all of its fields, to the selection.
+func (sel *Selection) AddType(name string) {
+	sel.allFields[name] = true
+}
+
+// AddField adds the named field, and the type it is defined on, to the selection.
+func (sel *Selection) AddField(typeName, fieldName string) {
+	if sel.fields[typeName] == nil {
+		sel.fields[typeName] = map[string]bool{}
+	}
+	sel.fields[typeName][fieldName] = true
+}
+
+// AddOperations adds all of the fields selected by the given operations to the selection. The given document
+// must have already been validated against the schema, since validation annotates the selections in the
+// document with their definitions.
+func (sel *Selection) AddOperations(doc *ast.QueryDocument) {
+	seenFragments := map[string]bool{}
+
+	var walk func(ss ast.SelectionSet)
+	walk = func(ss ast.SelectionSet) {
+		for _, s := range ss {
+			switch s := s.(type) {
+			case *ast.Field:
+				if s.ObjectDefinition != nil && !astutil.IsBuiltinField(s.Name) {
+					sel.AddField(s.ObjectDefinition.Name, s.Name)
+				}
+				walk(s.SelectionSet)
+			case *ast.InlineFragment:
+				walk(s.SelectionSet)
+			case *ast.FragmentSpread:
+				if s.Definition == nil || seenFragments[s.Name] {
+					continue
+				}
+				seenFragments[s.Name] = true
+				walk(s.Definition.SelectionSet)
+			}
+		}
+	}
+
+	for _, op := range doc.Operations {
+		walk(op.SelectionSet)
+	}
+}
+
+// Extract returns a new schema containing only the selected types and fields from s, along with everything
+// needed to make the result a valid schema on its own:
+//
+//   - Scalar, enum, and input object types referenced by included fields, arguments, or directives are
+//     included in full.
+//   - Interfaces implemented by included object and interface types are included, along with those of their
+//     fields which are included on at least one implementation. Implementations of an included interface
+//     include all of the interface's included fields.
+//   - Definitions of the non-built-in directives applied anywhere within the result are included.
+//   - If the query root type is not selected, a Query type with a single placeholder field is synthesized. If
+//     a type named Query is part of the result, the placeholder is named _Query instead.
+//
+// Fields whose type is an object, interface, or union which is not selected are omitted, as are types with no
+// remaining fields or possible types. Built-in types and directives are omitted.
+func Extract(s *ast.Schema, sel *Selection) *ast.Schema {
+	e := &extractor{
+		schema:        s,
+		kept:          map[string]map[string]bool{},
+		removed:       map[string]bool{},
+		removedFields: map[string]map[string]bool{},
+		directives:    map[string]bool{},
+	}
+
+	for _, name := range astutil.SortedKeys(sel.allFields) {
+		e.addType(name)
+	}
+	for _, typeName := range astutil.SortedKeys(sel.fields) {
+		def := e.definition(typeName)
+		if def == nil {
+			continue
+		}
+		for _, fieldName := range astutil.SortedKeys(sel.fields[typeName]) {
+			if def.Fields.ForName(fieldName) != nil {
+				e.keepField(typeName, fieldName)
+			}
+		}
+	}
+
+	for {
+		for e.changed = true; e.changed; {
+			e.changed = false
+			e.pass()
+		}
+		if !e.removeEmptyTypes() {
+			break
+		}
+	}
+
+	return e.build()
+}
+
+type extractor struct {
+	schema        *ast.Schema
+	kept          map[string]map[string]bool
+	removed       map[string]bool
+	removedFields map[string]map[string]bool
+	directives    map[string]bool
+	changed       bool
+}
+
+// definition returns the named type from the schema, or nil if it is missing or built-in.
+func (e *extractor) definition(name string) *ast.Definition {
+	if astutil.IsBuiltinType(name) {
+		return nil
+	}
+	return e.schema.Types[name]
+}
+
+func (e *extractor) isKept(name string) bool {
+	_, ok := e.kept[name]
+	return ok
+}
+
+// addType includes the named type along with all of its fields.
+func (e *extractor) addType(name string) {
+	def := e.definition(name)
+	if def == nil || e.removed[name] {
+		return
+	}
+	if !e.isKept(name) {
+		e.kept[name] = map[string]bool{}
+		e.changed = true
+	}
+	for _, f := range def.Fields {
+		e.keepField(name, f.Name)
+	}
+}
+
+func (e *extractor) keepField(typeName, fieldName string) {
+	if e.removed[typeName] || e.removedFields[typeName][fieldName] {
+		return
+	}
+	if !e.isKept(typeName) {
+		e.kept[typeName] = map[string]bool{}
+	}
+	if !e.kept[typeName][fieldName] {
+		e.kept[typeName][fieldName] = true
+		e.changed = true
+	}
+}
+
+func (e *extractor) removeField(typeName, fieldName string) {
+	delete(e.kept[typeName], fieldName)
+	if e.removedFields[typeName] == nil {
+		e.removedFields[typeName] = map[string]bool{}
+	}
+	e.removedFields[typeName][fieldName] = true
+	e.changed = true
+}
+
+func (e *extractor) removeType(name string) {
+	delete(e.kept, name)
+	e.removed[name] = true
+	e.changed = true
+}
+
+// addDependency includes the named type if it is referenced by an included field, argument, or directive,
+// and reports whether it is (or was already) included. Leaf, input, and interface types are included in full,
+// and unions are included with only their included possible types. Object types are never included as
+// dependencies: they must be selected.
+func (e *extractor) addDependency(name string) bool {
+	def := e.definition(name)
+	if def == nil {
+		return true
+	}
+	if e.isKept(name) {
+		return true
+	}
+	switch def.Kind {
+	case ast.Scalar, ast.Enum, ast.InputObject, ast.Interface:
+		e.addType(name)
+	case ast.Union:
+		if !e.removed[name] {
+			e.kept[name] = map[string]bool{}
+			e.changed = true
+		}
+	}
+	return e.isKept(name)
+}
+
+func (e *extractor) addDirectives(dl ast.DirectiveList) {
+	for _, d := range dl {
+		def := e.schema.Directives[d.Name]
+		if def == nil || astutil.IsBuiltinDirective(d.Name) || e.directives[d.Name] {
+			continue
+		}
+		e.directives[d.Name] = true
+		e.changed = true
+		for _, arg := range def.Arguments {
+			e.addDependency(arg.Type.Name())
+		}
+	}
+}
+
+// pass makes a single pass over the included types, adding their dependencies and removing anything which
+// can't be included.
+func (e *extractor) pass() {
+	for _, name := range astutil.SortedKeys(e.kept) {
+		if !e.isKept(name) {
+			continue
+		}
+		def := e.schema.Types[name]
+		e.addDirectives(def.Directives)
+
+		switch def.Kind {
+		case ast.Object, ast.Interface, ast.InputObject:
+			for _, f := range def.Fields {
+				if !e.kept[name][f.Name] {
+					continue
+				}
+				if !e.addDependency(f.Type.Name()) {
+					e.removeField(name, f.Name)
+					continue
+				}
+				e.addDirectives(f.Directives)
+				for _, arg := range f.Arguments {
+					e.addDependency(arg.Type.Name())
+					e.addDirectives(arg.Directives)
+				}
+			}
+			for _, iface := range def.Interfaces {
+				e.addInterface(name, iface)
+			}
+		case ast.Enum:
+			for _, ev := range def.EnumValues {
+				e.addDirectives(ev.Directives)
+			}
+		}
+	}
+
+	for _, name := range astutil.SortedKeys(e.kept) {
+		def := e.schema.Types[name]
+		if def.Kind == ast.Interface {
+			e.enforceInterface(def)
+		}
+	}
+}
+
+// removeEmptyTypes removes object and interface types with no included fields, and unions with no included
+// possible types, and reports whether any were removed.
+func (e *extractor) removeEmptyTypes() bool {
+	removed := false
+	for _, name := range astutil.SortedKeys(e.kept) {
+		def := e.schema.Types[name]
+		empty := false
+		switch def.Kind {
+		case ast.Object, ast.Interface:
+			empty = len(e.kept[name]) == 0
+		case ast.Union:
+			empty = len(e.keptPossibleTypes(def)) == 0
+		}
+		if empty {
+			e.removeType(name)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// addInterface includes the named interface implemented by the given type, along with any of its fields which
+// are included on the implementing type.
+func (e *extractor) addInterface(implementor, ifaceName string) {
+	iface := e.definition(ifaceName)
+	if iface == nil || e.removed[ifaceName] {
+		return
+	}
+	for _, f := range iface.Fields {
+		if e.kept[implementor][f.Name] {
+			e.keepField(ifaceName, f.Name)
+		}
+	}
+}
+
+// enforceInterface ensures that every included implementation of the given interface includes all of the
+// interface's included fields. Fields which can't be included on an implementation are removed from the
+// interface instead.
+func (e *extractor) enforceInterface(iface *ast.Definition) {
+	for _, name := range astutil.SortedKeys(e.kept) {
+		def := e.schema.Types[name]
+		if !implements(def, iface.Name) {
+			continue
+		}
+		for _, fieldName := range astutil.SortedKeys(e.kept[iface.Name]) {
+			if e.kept[name][fieldName] {
+				continue
+			}
+			f := def.Fields.ForName(fieldName)
+			if f != nil && !e.removedFields[name][fieldName] && e.addDependency(f.Type.Name()) {
+				e.keepField(name, fieldName)
+			} else {
+				e.removeField(iface.Name, fieldName)
+			}
+		}
+	}
+}
+
+func (e *extractor) keptPossibleTypes(union *ast.Definition) []string {
+	var result []string
+	for _, member := range union.Types {
+		if e.isKept(member) {
+			result = append(result, member)
+		}
+	}
+	return result
+}
+
+func (e *extractor) build() *ast.Schema {
+	out := &ast.Schema{
+		Types:      map[string]*ast.Definition{},
+		Directives: map[string]*ast.DirectiveDefinition{},
+	}
+
+	for name := range e.kept {
+		out.Types[name] = e.buildDefinition(e.schema.Types[name])
+	}
+
+	for name := range e.directives {
+		out.Directives[name] = e.schema.Directives[name]
+	}
+
+	astutil.LinkPossibleTypes(out)
+
+	if e.schema.Query != nil && e.isKept(e.schema.Query.Name) {
+		out.Query = out.Types[e.schema.Query.Name]
+	} else {
+		out.Query = placeholderQueryType(placeholderQueryTypeName(out.Types))
+		out.Types[out.Query.Name] = out.Query
+	}
+	if e.schema.Mutation != nil && e.isKept(e.schema.Mutation.Name) {
+		out.Mutation = out.Types[e.schema.Mutation.Name]
+	}
+	if e.schema.Subscription != nil && e.isKept(e.schema.Subscription.Name) {
+		out.Subscription = out.Types[e.schema.Subscription.Name]
+	}
+
+	return out
+}
+
+// buildDefinition returns a copy of the given definition, trimmed down to its included fields, interfaces,
+// and possible types.
+func (e *extractor) buildDefinition(def *ast.Definition) *ast.Definition {
+	result := *def
+
+	if def.Kind != ast.InputObject {
+		result.Fields = nil
+		for _, f := range def.Fields {
+			if e.kept[def.Name][f.Name] {
+				result.Fields = append(result.Fields, f)
+			}
+		}
+	}
+
+	result.Interfaces = nil
+	for _, iface := range def.Interfaces {
+		if e.isKept(iface) {
+			result.Interfaces = append(result.Interfaces, iface)
+		}
+	}
+
+	if def.Kind == ast.Union {
+		result.Types = e.keptPossibleTypes(def)
+	}
+
+	return &result
+}
+
+// placeholderQueryTypeName returns a name for the placeholder query root type which doesn't collide with any
+// of the given types, preferring Query, and then _Query.
+func placeholderQueryTypeName(types map[string]*ast.Definition) string {
+	name := "Query"
+	for i := 1; types[name] != nil; i++ {
+		name = "_Query"
+		if i > 1 {
+			name += strconv.Itoa(i)
+		}
+	}
+	return name
+}
+
+func placeholderQueryType(name string) *ast.Definition {
+	return &ast.Definition{
+		Kind:        ast.Object,
+		Name:        name,
+		Description: "Placeholder query root type, since the original query root type is not part of this schema.",
+		Fields: ast.FieldList{
+			{
+				Name: PlaceholderFieldName,
+				Type: ast.NamedType("Boolean", nil),
+			},
+		},
+	}
+}
+
+func implements(def *ast.Definition, ifaceName string) bool {
+	for _, iface := range def.Interfaces {
+		if iface == ifaceName {
+			return true
+		}
+	}
+	return false
+}
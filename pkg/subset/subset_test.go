@@ -0,0 +1,235 @@
+package subset
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/benweint/gquil/pkg/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+const testSchema = `directive @tag(name: String!, scope: Scope) on FIELD_DEFINITION | OBJECT
+directive @unused on FIELD_DEFINITION
+
+scalar DateTime
+
+enum Scope { PUBLIC, PRIVATE }
+
+type Query {
+	node(id: ID!): Node
+	user(id: ID!): User
+	search(filter: SearchFilter): [SearchResult]
+	internal: String @unused
+}
+
+type Mutation {
+	deleteUser(id: ID!): Boolean
+}
+
+interface Node {
+	id: ID!
+}
+
+type User implements Node @tag(name: "user") {
+	id: ID!
+	name: String
+	createdAt: DateTime @tag(name: "created", scope: PUBLIC)
+	friends: [User]
+	posts: [Post]
+}
+
+type Post implements Node {
+	id: ID!
+	title: String
+	author: User
+}
+
+union SearchResult = User | Post
+
+input SearchFilter {
+	text: String
+	after: DateTime
+}`
+
+func TestExtract(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sel      func(sel *Selection)
+		expected string
+	}{
+		{
+			name: "single field",
+			sel: func(sel *Selection) {
+				sel.AddField("Post", "title")
+			},
+			expected: `type Post {
+	title: String
+}
+"""
+Placeholder query root type, since the original query root type is not part of this schema.
+"""
+type Query {
+	_placeholder: Boolean
+}
+`,
+		},
+		{
+			name: "fields referencing unselected types are omitted",
+			sel: func(sel *Selection) {
+				sel.AddType("Post")
+				sel.AddField("Query", "search")
+			},
+			expected: `scalar DateTime
+interface Node {
+	id: ID!
+}
+type Post implements Node {
+	id: ID!
+	title: String
+}
+type Query {
+	search(filter: SearchFilter): [SearchResult]
+}
+input SearchFilter {
+	text: String
+	after: DateTime
+}
+union SearchResult = Post
+`,
+		},
+		{
+			name: "unions only include selected possible types",
+			sel: func(sel *Selection) {
+				sel.AddField("Query", "search")
+				sel.AddField("Post", "title")
+			},
+			expected: `scalar DateTime
+type Post {
+	title: String
+}
+type Query {
+	search(filter: SearchFilter): [SearchResult]
+}
+input SearchFilter {
+	text: String
+	after: DateTime
+}
+union SearchResult = Post
+`,
+		},
+		{
+			name: "interface implementations and directives",
+			sel: func(sel *Selection) {
+				sel.AddField("Query", "node")
+				sel.AddField("User", "createdAt")
+			},
+			expected: `directive @tag(name: String!, scope: Scope) on FIELD_DEFINITION | OBJECT
+scalar DateTime
+interface Node {
+	id: ID!
+}
+type Query {
+	node(id: ID!): Node
+}
+enum Scope {
+	PUBLIC
+	PRIVATE
+}
+type User implements Node @tag(name: "user") {
+	id: ID!
+	createdAt: DateTime @tag(name: "created", scope: PUBLIC)
+}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+			sel := NewSelection()
+			tc.sel(sel)
+
+			actual := format(Extract(s, sel))
+			assert.Equal(t, tc.expected, actual)
+			assertValid(t, actual)
+		})
+	}
+}
+
+func TestExtractPlaceholderNameCollision(t *testing.T) {
+	s := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: `schema { query: Root }
+type Root { a: Int }
+type Query { b: Int }
+type _Query { c: Int }`})
+
+	sel := NewSelection()
+	sel.AddType("Query")
+	sel.AddType("_Query")
+
+	actual := format(Extract(s, sel))
+	assert.Equal(t, `schema {
+	query: _Query2
+}
+type Query {
+	b: Int
+}
+type _Query {
+	c: Int
+}
+"""
+Placeholder query root type, since the original query root type is not part of this schema.
+"""
+type _Query2 {
+	_placeholder: Boolean
+}
+`, actual)
+	assertValid(t, actual)
+}
+
+func TestExtractOperations(t *testing.T) {
+	s := gqlparser.MustLoadSchema(&ast.Source{Name: "schema", Input: testSchema})
+	doc, errs := operations.Parse([]*ast.Source{{Name: "ops", Input: `
+		query GetUser { user(id: "1") { ...UserFields } }
+		mutation DeleteUser { deleteUser(id: "1") }
+		fragment UserFields on User { name posts { title } }
+	`}})
+	assert.Empty(t, errs)
+	assert.Empty(t, operations.Validate(s, doc))
+
+	sel := NewSelection()
+	sel.AddOperations(doc)
+
+	actual := format(Extract(s, sel))
+	assert.Equal(t, `directive @tag(name: String!, scope: Scope) on FIELD_DEFINITION | OBJECT
+type Mutation {
+	deleteUser(id: ID!): Boolean
+}
+type Post {
+	title: String
+}
+type Query {
+	user(id: ID!): User
+}
+enum Scope {
+	PUBLIC
+	PRIVATE
+}
+type User @tag(name: "user") {
+	name: String
+	posts: [Post]
+}
+`, actual)
+	assertValid(t, actual)
+}
+
+func format(s *ast.Schema) string {
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatSchema(s)
+	return buf.String()
+}
+
+func assertValid(t *testing.T, sdl string) {
+	_, err := gqlparser.LoadSchema(&ast.Source{Name: "extracted", Input: sdl})
+	assert.NoError(t, err)
+}